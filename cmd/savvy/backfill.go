@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/heyajulia/savvy/internal"
+	"github.com/heyajulia/savvy/internal/config"
+	"github.com/heyajulia/savvy/internal/datetime"
+	"github.com/heyajulia/savvy/internal/history"
+	"github.com/urfave/cli/v3"
+)
+
+var errBackfillFailed = errors.New("some days could not be backfilled")
+
+func backfillCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "backfill",
+		Usage: "Fetch the prices of past days and store them in the history",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "from",
+				Usage:    "First day to fetch (YYYY-MM-DD)",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "to",
+				Usage: "Last day to fetch (YYYY-MM-DD, default: today)",
+			},
+			&cli.DurationFlag{
+				Name:  "delay",
+				Usage: "Time to wait between requests, to go easy on the price source",
+				Value: 2 * time.Second,
+			},
+			&cli.BoolFlag{
+				Name:  "force",
+				Usage: "Fetch days that are already in the history again",
+			},
+		},
+		Action: runBackfill,
+	}
+}
+
+func runBackfill(ctx context.Context, c *cli.Command) error {
+	slog.SetDefault(internal.Logger())
+
+	cfg, err := config.Read[config.Backfill]()
+	if err != nil {
+		slog.Error("configuration error", slog.Any("err", err))
+		os.Exit(1)
+	}
+
+	sources, err := newSources(cfg.Source)
+	if err != nil {
+		slog.Error("configuration error", slog.Any("err", err))
+		os.Exit(1)
+	}
+
+	from, err := parseDay(c.String("from"))
+	if err != nil {
+		return fmt.Errorf("parse --from: %w", err)
+	}
+
+	to := datetime.StartOfDay(datetime.Now())
+	if s := c.String("to"); s != "" {
+		if to, err = parseDay(s); err != nil {
+			return fmt.Errorf("parse --to: %w", err)
+		}
+	}
+
+	if to.Before(from) {
+		return fmt.Errorf("--to (%s) is before --from (%s)", to.Format(time.DateOnly), from.Format(time.DateOnly))
+	}
+
+	opts := backfillOptions{
+		Sources: sources,
+		Tariff:  cfg.Tariff,
+		History: history.New(cfg.HistoryDir),
+		Delay:   c.Duration("delay"),
+		Force:   c.Bool("force"),
+	}
+
+	return backfill(ctx, opts, from, to)
+}
+
+type backfillOptions struct {
+	Sources internal.Sources
+	Tariff  config.Tariff
+	History *history.Store
+
+	// Delay is the time to wait between fetches.
+	Delay time.Duration
+
+	// Force fetches days that are already in the history, instead of skipping them.
+	Force bool
+}
+
+// backfill fetches the prices of the days from from up to and including to, and saves them to the history. Days that
+// are already in the history are skipped, so an interrupted backfill can simply be run again to resume it. A day that
+// fails doesn't stop the backfill; instead, errBackfillFailed is returned at the end.
+func backfill(ctx context.Context, opts backfillOptions, from, to time.Time) error {
+	done := make(map[string]bool)
+
+	if !opts.Force {
+		days, err := opts.History.Days()
+		if err != nil {
+			return fmt.Errorf("list days in history: %w", err)
+		}
+
+		for _, day := range days {
+			done[day.Format(time.DateOnly)] = true
+		}
+	}
+
+	var fetched, skipped, failed int
+
+	for day := datetime.StartOfDay(from); !day.After(to); day = day.AddDate(0, 0, 1) {
+		logger := slog.With(slog.String("day", day.Format(time.DateOnly)))
+
+		if done[day.Format(time.DateOnly)] {
+			skipped++
+			continue
+		}
+
+		// Wait before every fetch but the first.
+		if fetched+failed > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(opts.Delay):
+			}
+		}
+
+		if err := backfillDay(ctx, opts, day); err != nil {
+			logger.Error("could not backfill day", slog.Any("err", err))
+			failed++
+
+			continue
+		}
+
+		logger.Info("backfilled day")
+		fetched++
+	}
+
+	slog.Info("backfill finished", slog.Int("fetched", fetched), slog.Int("skipped", skipped), slog.Int("failed", failed))
+
+	if failed > 0 {
+		return fmt.Errorf("%w: %d failed", errBackfillFailed, failed)
+	}
+
+	return nil
+}
+
+func backfillDay(ctx context.Context, opts backfillOptions, day time.Time) error {
+	tariff, err := tariffOn(opts.Tariff, day)
+	if err != nil {
+		return fmt.Errorf("get tariff: %w", err)
+	}
+
+	p, err := internal.GetEnergyPrices(ctx, opts.Sources, tariff, day)
+	if err != nil {
+		return fmt.Errorf("get energy prices: %w", err)
+	}
+
+	if err := opts.History.Save(p); err != nil {
+		return fmt.Errorf("save prices: %w", err)
+	}
+
+	return nil
+}
+
+// parseDay parses a date in the YYYY-MM-DD format as the start of that day in Amsterdam.
+func parseDay(s string) (time.Time, error) {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return datetime.StartOfDay(t), nil
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/heyajulia/savvy/internal"
+	"github.com/heyajulia/savvy/internal/config"
+	"github.com/heyajulia/savvy/internal/datetime"
+	"github.com/heyajulia/savvy/internal/history"
+	"github.com/heyajulia/savvy/internal/source"
+)
+
+// energyZeroServer stands in for the EnergyZero API. It returns hourly prices for every day, with as many prices as the
+// day has hours, except for the days in fail. It records the days it was asked for.
+type energyZeroServer struct {
+	mu        sync.Mutex
+	fail      map[string]bool
+	requested []string
+}
+
+func (s *energyZeroServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	from, err := time.Parse(time.RFC3339, r.URL.Query().Get("fromDate"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// fromDate is midnight in Amsterdam, expressed in UTC.
+	start := datetime.Local(from)
+	day := start.Format(time.DateOnly)
+
+	s.mu.Lock()
+	s.requested = append(s.requested, day)
+	fail := s.fail[day]
+	s.mu.Unlock()
+
+	if fail {
+		w.WriteHeader(http.StatusBadGateway)
+		return
+	}
+
+	// Days on which DST starts or ends have 23 or 25 hours.
+	prices := make([]string, int(datetime.Tomorrow(start).Sub(start)/time.Hour))
+	for i := range prices {
+		prices[i] = fmt.Sprintf(`{"price":0.%02d}`, i)
+	}
+
+	fmt.Fprintf(w, `{"Prices":[%s]}`, strings.Join(prices, ","))
+}
+
+func TestBackfill(t *testing.T) {
+	srv := &energyZeroServer{fail: map[string]bool{"2025-03-30": true}}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	primary, err := source.New("energyzero", source.Options{BaseURL: ts.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := history.New(t.TempDir())
+	opts := backfillOptions{
+		Sources: internal.Sources{Primary: primary},
+		Tariff:  config.Tariff{Wholesale: true},
+		History: h,
+	}
+
+	from, err := parseDay("2025-03-28")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	to, err := parseDay("2025-03-31")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := backfill(context.Background(), opts, from, to); !errors.Is(err, errBackfillFailed) {
+		t.Fatalf("got %v, want %v", err, errBackfillFailed)
+	}
+
+	assertDays(t, h, "2025-03-28", "2025-03-29", "2025-03-31")
+
+	// Running again only fetches the day that failed.
+	srv.requested = nil
+	delete(srv.fail, "2025-03-30")
+
+	if err := backfill(context.Background(), opts, from, to); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := strings.Join(srv.requested, ","); got != "2025-03-30" {
+		t.Errorf("got requests for %s, want only 2025-03-30", got)
+	}
+
+	assertDays(t, h, "2025-03-28", "2025-03-29", "2025-03-30", "2025-03-31")
+
+	// DST starts on 30 March, so that day has 23 hours.
+	dstStart, err := h.Load(time.Date(2025, time.March, 30, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dstStart.Len() != 23 {
+		t.Errorf("got %d prices for 2025-03-30, want 23", dstStart.Len())
+	}
+
+	// Forcing fetches every day again.
+	srv.requested = nil
+	opts.Force = true
+
+	if err := backfill(context.Background(), opts, from, to); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(srv.requested) != 4 {
+		t.Errorf("got requests for %v, want all 4 days", srv.requested)
+	}
+}
+
+func TestBackfillDelay(t *testing.T) {
+	ts := httptest.NewServer(&energyZeroServer{})
+	defer ts.Close()
+
+	primary, err := source.New("energyzero", source.Options{BaseURL: ts.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := backfillOptions{
+		Sources: internal.Sources{Primary: primary},
+		Tariff:  config.Tariff{Wholesale: true},
+		History: history.New(t.TempDir()),
+		Delay:   time.Hour,
+	}
+
+	from, _ := parseDay("2025-03-28")
+	to, _ := parseDay("2025-03-29")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// The first day is fetched right away, but the backfill is cancelled while it waits to fetch the second one.
+	if err := backfill(ctx, opts, from, to); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	assertDays(t, opts.History, "2025-03-28")
+}
+
+func assertDays(t *testing.T, h *history.Store, want ...string) {
+	t.Helper()
+
+	days, err := h.Days()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make([]string, 0, len(days))
+	for _, d := range days {
+		got = append(got, d.Format(time.DateOnly))
+	}
+
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("got days %v, want %v", got, want)
+	}
+}
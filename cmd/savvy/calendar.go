@@ -0,0 +1,288 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/heyajulia/savvy/internal"
+	"github.com/heyajulia/savvy/internal/config"
+	"github.com/heyajulia/savvy/internal/datetime"
+	"github.com/heyajulia/savvy/internal/ical"
+	"github.com/heyajulia/savvy/internal/prices"
+	"github.com/urfave/cli/v3"
+)
+
+// calendarWindow is the length of the cheapest window that the calendar shows, which is long enough to run most
+// appliances.
+const calendarWindow = 3 * time.Hour
+
+func calendarCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "calendar",
+		Usage: "Write or serve an iCalendar feed of the cheapest and most expensive hours",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "date",
+				Usage: "Day to write (YYYY-MM-DD, default: today and tomorrow)",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "File to write the calendar to, or - for standard output",
+				Value:   "-",
+			},
+			&cli.StringFlag{
+				Name:  "listen",
+				Usage: "Serve today's and tomorrow's calendar over HTTP on this address (such as :8080), instead of writing it",
+			},
+		},
+		Action: runCalendar,
+	}
+}
+
+func runCalendar(ctx context.Context, c *cli.Command) error {
+	slog.SetDefault(internal.Logger())
+
+	cfg, err := config.Read[config.Calendar]()
+	if err != nil {
+		slog.Error("configuration error", slog.Any("err", err))
+		os.Exit(1)
+	}
+
+	sources, err := newSources(cfg.Source)
+	if err != nil {
+		slog.Error("configuration error", slog.Any("err", err))
+		os.Exit(1)
+	}
+
+	h := newHistory(cfg.HistoryDir)
+
+	load := func(ctx context.Context, day time.Time) (*prices.Prices, error) {
+		p, err := dayPrices(ctx, sources, cfg.Tariff, h, day)
+		if err != nil {
+			return nil, err
+		}
+
+		if !cfg.QuarterHours {
+			p = p.Hourly()
+		}
+
+		return p, nil
+	}
+
+	if addr := c.String("listen"); addr != "" {
+		return serveCalendar(ctx, addr, newCalendarHandler(load))
+	}
+
+	var days []time.Time
+
+	if s := c.String("date"); s != "" {
+		day, err := parseDay(s)
+		if err != nil {
+			return fmt.Errorf("parse --date: %w", err)
+		}
+
+		days = append(days, day)
+	} else {
+		today := datetime.StartOfDay(datetime.Now())
+		days = append(days, today, datetime.Tomorrow(today))
+	}
+
+	var ps []*prices.Prices
+
+	for _, day := range days {
+		p, err := load(ctx, day)
+		if err != nil {
+			// Tomorrow's prices aren't published until the afternoon.
+			slog.Warn("no prices for day", slog.String("day", day.Format(time.DateOnly)), slog.Any("err", err))
+			continue
+		}
+
+		ps = append(ps, p)
+	}
+
+	b := newCalendar(ps).Marshal(time.Now())
+
+	if output := c.String("output"); output != "-" {
+		if err := os.WriteFile(output, b, 0644); err != nil {
+			return fmt.Errorf("write calendar: %w", err)
+		}
+
+		return nil
+	}
+
+	_, err = os.Stdout.Write(b)
+
+	return err
+}
+
+// newCalendar returns a calendar with events for the lowest and highest prices of every day, and for every day's
+// cheapest window of calendarWindow.
+func newCalendar(days []*prices.Prices) ical.Calendar {
+	c := ical.Calendar{Name: "Energieprijzen"}
+
+	for _, p := range days {
+		for _, w := range prices.Contiguous(p.LowHours()) {
+			c.Events = append(c.Events, calendarEvent(w, "low", prices.VeryCheap.Emoji()+" Laagste stroomprijs: "+prices.Format(w.Average)+" per kWh",
+				"De laagste stroomprijs van "+datetime.Format(p.Start())+"."))
+		}
+
+		for _, w := range prices.Contiguous(p.HighHours()) {
+			c.Events = append(c.Events, calendarEvent(w, "high", prices.VeryExpensive.Emoji()+" Hoogste stroomprijs: "+prices.Format(w.Average)+" per kWh",
+				"De hoogste stroomprijs van "+datetime.Format(p.Start())+". Stel het gebruik van grote apparaten liever uit."))
+		}
+
+		if w, err := p.CheapestWindow(calendarWindow); err == nil {
+			c.Events = append(c.Events, calendarEvent(w, "cheapest", prices.Cheap.Emoji()+" Goedkoopste 3 uur: gemiddeld "+prices.Format(w.Average)+" per kWh",
+				"Een goed moment om de wasmachine, de vaatwasser of de auto te laten draaien of laden."))
+		}
+	}
+
+	return c
+}
+
+func calendarEvent(w prices.Window, kind, summary, description string) ical.Event {
+	return ical.Event{
+		UID:         fmt.Sprintf("%s-%s@savvy", w.Start().UTC().Format("20060102T150405Z"), kind),
+		Start:       w.Start(),
+		End:         w.End(),
+		Summary:     summary,
+		Description: description,
+	}
+}
+
+// calendarRetry is how long the calendar waits before it tries to load a day's prices again, after it couldn't. Until
+// tomorrow's prices are published, this keeps every calendar app that polls from hitting the price sources.
+const calendarRetry = 15 * time.Minute
+
+// calendarHandler serves a calendar of today and tomorrow. Prices that were loaded once are remembered, because they
+// never change.
+type calendarHandler struct {
+	load func(ctx context.Context, day time.Time) (*prices.Prices, error)
+
+	mu    sync.Mutex
+	cache map[string]*calendarEntry
+}
+
+// calendarEntry holds a day's prices, or the error that loading them returned. Its fields are set when done is closed.
+type calendarEntry struct {
+	done   chan struct{}
+	prices *prices.Prices
+	err    error
+
+	// expires is when a failed load may be tried again.
+	expires time.Time
+}
+
+func newCalendarHandler(load func(ctx context.Context, day time.Time) (*prices.Prices, error)) *calendarHandler {
+	return &calendarHandler{load: load, cache: make(map[string]*calendarEntry)}
+}
+
+func (h *calendarHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	today := datetime.StartOfDay(datetime.Now())
+
+	h.prune(today)
+
+	var ps []*prices.Prices
+
+	for _, day := range []time.Time{today, datetime.Tomorrow(today)} {
+		if p := h.get(r.Context(), day); p != nil {
+			ps = append(ps, p)
+		}
+	}
+
+	w.Header().Set("Content-Type", ical.ContentType)
+	_, _ = w.Write(newCalendar(ps).Marshal(time.Now()))
+}
+
+// get returns the prices of the given day, or nil if they can't be loaded (yet). Requests for a day that is being loaded
+// wait for that load instead of starting another one, and the lock isn't held while loading, so other days are served
+// in the meantime.
+func (h *calendarHandler) get(ctx context.Context, day time.Time) *prices.Prices {
+	key := day.Format(time.DateOnly)
+
+	h.mu.Lock()
+
+	e, ok := h.cache[key]
+	if ok && e.failed(time.Now()) {
+		ok = false
+	}
+
+	if !ok {
+		e = &calendarEntry{done: make(chan struct{})}
+		h.cache[key] = e
+	}
+
+	h.mu.Unlock()
+
+	if ok {
+		select {
+		case <-e.done:
+			return e.prices
+		case <-ctx.Done():
+			return nil
+		}
+	}
+
+	// Other requests wait for this load, so it must not be cancelled when this request is.
+	e.prices, e.err = h.load(context.WithoutCancel(ctx), day)
+	if e.err != nil {
+		slog.Warn("no prices for day", slog.String("day", key), slog.Any("err", e.err))
+		e.expires = time.Now().Add(calendarRetry)
+	}
+
+	close(e.done)
+
+	return e.prices
+}
+
+// failed reports whether loading the entry failed long enough ago that it should be tried again.
+func (e *calendarEntry) failed(now time.Time) bool {
+	select {
+	case <-e.done:
+		return e.err != nil && !now.Before(e.expires)
+	default:
+		return false
+	}
+}
+
+// prune forgets the prices of the days before today, so that the cache doesn't grow forever.
+func (h *calendarHandler) prune(today time.Time) {
+	key := today.Format(time.DateOnly)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for k := range h.cache {
+		if k < key {
+			delete(h.cache, k)
+		}
+	}
+}
+
+func serveCalendar(ctx context.Context, addr string, handler http.Handler) error {
+	srv := &http.Server{Addr: addr, Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	slog.Info("serving calendar", slog.String("addr", addr))
+
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serve calendar: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/heyajulia/savvy/internal/datetime"
+	"github.com/heyajulia/savvy/internal/ical"
+	"github.com/heyajulia/savvy/internal/prices"
+	"github.com/heyajulia/savvy/internal/prices/pricestest"
+)
+
+func TestNewCalendarDST(t *testing.T) {
+	loc := datetime.Location()
+
+	// On 30 March 2025, clocks skip from 02:00 to 03:00, so the day has 23 hours.
+	ps := pricestest.Constant(23, 0.2)
+	ps[1], ps[2] = 0.05, 0.05 // 01:00 and 03:00
+	ps[19] = 0.4              // 20:00
+
+	p := prices.New(time.Date(2025, time.March, 30, 0, 0, 0, 0, loc), time.Hour, ps, prices.Wholesale)
+
+	c := newCalendar([]*prices.Prices{p})
+
+	if len(c.Events) != 3 {
+		t.Fatalf("got %d events, want 3", len(c.Events))
+	}
+
+	low, high, cheapest := c.Events[0], c.Events[1], c.Events[2]
+
+	// 01:00 CET to 04:00 CEST is two hours.
+	if !low.Start.Equal(time.Date(2025, time.March, 30, 0, 0, 0, 0, time.UTC)) || !low.End.Equal(time.Date(2025, time.March, 30, 2, 0, 0, 0, time.UTC)) {
+		t.Errorf("got low event from %v to %v", low.Start.UTC(), low.End.UTC())
+	}
+
+	if low.UID != "20250330T000000Z-low@savvy" || !strings.Contains(low.Summary, "Laagste stroomprijs: €\u00a00,05") {
+		t.Errorf("unexpected low event %+v", low)
+	}
+
+	if !high.Start.Equal(time.Date(2025, time.March, 30, 20, 0, 0, 0, loc)) || high.End.Sub(high.Start) != time.Hour {
+		t.Errorf("got high event from %v to %v", high.Start, high.End)
+	}
+
+	if !cheapest.Start.Equal(time.Date(2025, time.March, 30, 0, 0, 0, 0, loc)) || cheapest.End.Sub(cheapest.Start) != 3*time.Hour {
+		t.Errorf("got cheapest window from %v to %v", cheapest.Start, cheapest.End)
+	}
+}
+
+func TestCalendarHandler(t *testing.T) {
+	ps := make([]float64, 24)
+	for i := range ps {
+		ps[i] = float64(i) / 100
+	}
+
+	p := prices.New(time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC), time.Hour, ps, prices.Wholesale)
+
+	var calls int
+
+	h := newCalendarHandler(func(ctx context.Context, day time.Time) (*prices.Prices, error) {
+		calls++
+
+		// Tomorrow's prices aren't there yet.
+		if calls > 1 {
+			return nil, errors.New("not published yet")
+		}
+
+		return p, nil
+	})
+
+	for range 2 {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+
+		if got := rec.Header().Get("Content-Type"); got != ical.ContentType {
+			t.Errorf("got content type %q", got)
+		}
+
+		if got := strings.Count(rec.Body.String(), "BEGIN:VEVENT"); got != 3 {
+			t.Errorf("got %d events, want 3", got)
+		}
+	}
+
+	// Today's prices are loaded once, and tomorrow's aren't tried again until the failure expires.
+	if calls != 2 {
+		t.Errorf("got %d calls, want 2", calls)
+	}
+
+	h.cache[datetime.Tomorrow(datetime.StartOfDay(datetime.Now())).Format(time.DateOnly)].expires = time.Now()
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+
+	if calls != 3 {
+		t.Errorf("got %d calls after the failure expired, want 3", calls)
+	}
+}
+
+func TestCalendarHandlerConcurrent(t *testing.T) {
+	today := datetime.StartOfDay(datetime.Now())
+	tomorrow := datetime.Tomorrow(today)
+
+	p := prices.New(today, time.Hour, pricestest.Constant(24, 0.1), prices.Wholesale)
+
+	var calls atomic.Int32
+
+	release := make(chan struct{})
+
+	h := newCalendarHandler(func(ctx context.Context, day time.Time) (*prices.Prices, error) {
+		calls.Add(1)
+
+		if day.Equal(tomorrow) {
+			<-release
+		}
+
+		return p, nil
+	})
+
+	var wg sync.WaitGroup
+
+	for range 3 {
+		wg.Go(func() {
+			if got := h.get(t.Context(), tomorrow); got != p {
+				t.Errorf("got %v, want the loaded prices", got)
+			}
+		})
+	}
+
+	// While tomorrow is being loaded, today can still be served.
+	if got := h.get(t.Context(), today); got != p {
+		t.Errorf("got %v, want the loaded prices", got)
+	}
+
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 2 {
+		t.Errorf("got %d calls, want 2", got)
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/heyajulia/savvy/internal"
+	"github.com/heyajulia/savvy/internal/chart"
+	"github.com/heyajulia/savvy/internal/config"
+	"github.com/heyajulia/savvy/internal/datetime"
+	"github.com/heyajulia/savvy/internal/history"
+	"github.com/heyajulia/savvy/internal/prices"
+	"github.com/urfave/cli/v3"
+)
+
+var errUnknownFormat = errors.New("unknown format")
+
+func chartCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "chart",
+		Usage: "Write a chart of a day's hourly prices as an SVG or PNG image",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "date",
+				Usage: "Day to chart (YYYY-MM-DD, default: tomorrow)",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "Image format, svg or png (default: from the output file's extension, or svg)",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "File to write the chart to, or - for standard output",
+				Value:   "-",
+			},
+		},
+		Action: runChart,
+	}
+}
+
+func runChart(ctx context.Context, c *cli.Command) error {
+	slog.SetDefault(internal.Logger())
+
+	cfg, err := config.Read[config.Chart]()
+	if err != nil {
+		slog.Error("configuration error", slog.Any("err", err))
+		os.Exit(1)
+	}
+
+	sources, err := newSources(cfg.Source)
+	if err != nil {
+		slog.Error("configuration error", slog.Any("err", err))
+		os.Exit(1)
+	}
+
+	day := datetime.Tomorrow(datetime.Now())
+	if s := c.String("date"); s != "" {
+		if day, err = parseDay(s); err != nil {
+			return fmt.Errorf("parse --date: %w", err)
+		}
+	}
+
+	output := c.String("output")
+
+	format, err := chartFormat(c.String("format"), output)
+	if err != nil {
+		return err
+	}
+
+	h := newHistory(cfg.HistoryDir)
+
+	p, err := dayPrices(ctx, sources, cfg.Tariff, h, day)
+	if err != nil {
+		return err
+	}
+
+	image, err := renderChart(p.Hourly(), format)
+	if err != nil {
+		return err
+	}
+
+	if output == "-" {
+		_, err = os.Stdout.Write(image)
+		return err
+	}
+
+	if err := os.WriteFile(output, image, 0644); err != nil {
+		return fmt.Errorf("write chart: %w", err)
+	}
+
+	slog.Info("chart written", slog.String("day", day.Format(time.DateOnly)), slog.String("file", output))
+
+	return nil
+}
+
+// chartFormat returns the image format to write. If format is empty, it is derived from the output file's extension,
+// and is SVG when there is none, such as for standard output.
+func chartFormat(format, output string) (string, error) {
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(output)), ".")
+		if format == "" {
+			format = "svg"
+		}
+	}
+
+	switch format {
+	case "svg", "png":
+		return format, nil
+	default:
+		return "", fmt.Errorf("%w: %q", errUnknownFormat, format)
+	}
+}
+
+func renderChart(p *prices.Prices, format string) ([]byte, error) {
+	if format == "png" {
+		return chart.PNG(p)
+	}
+
+	return chart.SVG(p), nil
+}
+
+// dayPrices returns the prices of the given day. They come from the history if it has them, and from the price
+// sources otherwise. h may be nil.
+func dayPrices(ctx context.Context, sources internal.Sources, tariffCfg config.Tariff, h *history.Store, day time.Time) (*prices.Prices, error) {
+	if h != nil {
+		p, err := h.Load(day)
+		if err == nil {
+			return p, nil
+		}
+
+		if !errors.Is(err, history.ErrNotFound) {
+			slog.Warn("could not load prices from history", slog.Any("err", err))
+		}
+	}
+
+	tariff, err := tariffOn(tariffCfg, day)
+	if err != nil {
+		return nil, fmt.Errorf("get tariff: %w", err)
+	}
+
+	p, err := internal.GetEnergyPrices(ctx, sources, tariff, day)
+	if err != nil {
+		return nil, fmt.Errorf("get energy prices: %w", err)
+	}
+
+	return p, nil
+}
+
+// newHistory returns the history in dir, or nil if dir is empty.
+func newHistory(dir string) *history.Store {
+	if dir == "" {
+		return nil
+	}
+
+	return history.New(dir)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/heyajulia/savvy/internal"
+	"github.com/heyajulia/savvy/internal/config"
+	"github.com/heyajulia/savvy/internal/history"
+	"github.com/heyajulia/savvy/internal/prices"
+	"github.com/heyajulia/savvy/internal/source"
+)
+
+func TestChartFormat(t *testing.T) {
+	tests := []struct {
+		format, output string
+		want           string
+	}{
+		{"", "-", "svg"},
+		{"", "prices.svg", "svg"},
+		{"", "prices.PNG", "png"},
+		{"", "prices", "svg"},
+		{"png", "-", "png"},
+		{"svg", "prices.png", "svg"},
+	}
+
+	for _, tt := range tests {
+		got, err := chartFormat(tt.format, tt.output)
+		if err != nil {
+			t.Errorf("chartFormat(%q, %q): %v", tt.format, tt.output, err)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf("chartFormat(%q, %q) = %q, want %q", tt.format, tt.output, got, tt.want)
+		}
+	}
+
+	if _, err := chartFormat("gif", "-"); !errors.Is(err, errUnknownFormat) {
+		t.Errorf("got %v, want %v", err, errUnknownFormat)
+	}
+
+	if _, err := chartFormat("", "prices.jpg"); !errors.Is(err, errUnknownFormat) {
+		t.Errorf("got %v for an unknown extension, want %v", err, errUnknownFormat)
+	}
+}
+
+func TestDayPrices(t *testing.T) {
+	srv := &energyZeroServer{}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	primary, err := source.New("energyzero", source.Options{BaseURL: ts.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sources := internal.Sources{Primary: primary}
+	h := history.New(t.TempDir())
+
+	stored, _ := parseDay("2025-03-28")
+	if err := h.Save(prices.New(stored, time.Hour, make([]float64, 24), prices.Wholesale)); err != nil {
+		t.Fatal(err)
+	}
+
+	// A day in the history isn't fetched.
+	p, err := dayPrices(context.Background(), sources, config.Tariff{Wholesale: true}, h, stored)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.High() != 0 || len(srv.requested) != 0 {
+		t.Errorf("expected the stored prices, got a high of %v after %d requests", p.High(), len(srv.requested))
+	}
+
+	// Other days are.
+	missing, _ := parseDay("2025-03-29")
+
+	p, err = dayPrices(context.Background(), sources, config.Tariff{Wholesale: true}, h, missing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.High() != 0.23 || len(srv.requested) != 1 {
+		t.Errorf("expected fetched prices, got a high of %v after %d requests", p.High(), len(srv.requested))
+	}
+}
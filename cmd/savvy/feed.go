@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/heyajulia/savvy/internal/atom"
+)
+
+// appendToFeed adds the long report, which was posted to Telegram at url, to the Atom feed at path. The feed keeps the
+// newest keep reports.
+func appendToFeed(path string, keep int, channelName, title, report, url string, now time.Time) error {
+	body, err := atom.FromTelegramHTML(report)
+	if err != nil {
+		return fmt.Errorf("convert report: %w", err)
+	}
+
+	channel := "https://t.me/" + channelName
+
+	feed := atom.Feed{
+		ID:     channel,
+		Title:  "Energieprijzen",
+		Author: atom.Person{Name: "Savvy", URI: "https://github.com/heyajulia/savvy"},
+		Links:  []atom.Link{{Rel: "alternate", Href: channel}},
+	}
+
+	entry := atom.Entry{
+		ID:      url,
+		Title:   title,
+		Updated: atom.FormatTime(now),
+		Links:   []atom.Link{{Rel: "alternate", Href: url}},
+		Content: atom.XHTML(body),
+	}
+
+	if err := atom.Append(path, feed, entry, keep); err != nil {
+		return err
+	}
+
+	slog.Info("report added to feed", slog.String("file", path))
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/heyajulia/savvy/internal/atom"
+)
+
+func TestAppendToFeed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "energieprijzen.xml")
+
+	_, long, err := report(templateData{
+		TomorrowDate:     "zaterdag 15 maart 2025",
+		AverageFormatted: "€\u00a00,24",
+		HighFormatted:    "€\u00a00,61",
+		LowFormatted:     "€\u00a0-0,12",
+		Slots:            []slot{{Emoji: "💚", Start: "00:00", End: "00:59", FormattedPrice: "€\u00a00,24"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2025, time.March, 14, 14, 0, 0, 0, time.UTC)
+	if err := appendToFeed(path, 30, "energieprijzen", "zaterdag 15 maart 2025", long, "https://t.me/energieprijzen/123", now); err != nil {
+		t.Fatal(err)
+	}
+
+	feed, err := atom.Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.ID != "https://t.me/energieprijzen" || len(feed.Entries) != 1 {
+		t.Fatalf("got feed %q with %d entries", feed.ID, len(feed.Entries))
+	}
+
+	e := feed.Entries[0]
+
+	if e.ID != "https://t.me/energieprijzen/123" || e.Title != "zaterdag 15 maart 2025" || e.Updated != "2025-03-14T14:00:00Z" {
+		t.Errorf("unexpected entry %+v", e)
+	}
+
+	if body := e.Content.Body(); !strings.HasPrefix(body, "Energieprijzen zaterdag 15 maart 2025: gemiddeld") || !strings.Contains(body, "<blockquote><code>💚 00:00 – 00:59") {
+		t.Errorf("unexpected body %q", body)
+	}
+}
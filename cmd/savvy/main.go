@@ -17,6 +17,11 @@ func main() {
 		Commands: []*cli.Command{
 			serveCommand(),
 			reportCommand(),
+			backfillCommand(),
+			chartCommand(),
+			previewCommand(),
+			pricesCommand(),
+			calendarCommand(),
 			upgradeCommand(),
 		},
 	}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/heyajulia/savvy/internal"
+	"github.com/heyajulia/savvy/internal/config"
+	"github.com/heyajulia/savvy/internal/mastodon"
+)
+
+// postToMastodon posts the report with a link to the full report on Telegram. It does nothing when Mastodon isn't
+// configured.
+func postToMastodon(report string, cfg config.Mastodon, url string) error {
+	if cfg.Server == "" {
+		return nil
+	}
+
+	status, err := mastodon.NewClient(cfg.Server, cfg.Token).PostStatus(mastodonText(report, url), cfg.Visibility)
+	if err != nil {
+		return fmt.Errorf("post status: %w", err)
+	}
+
+	slog.Info("status posted to mastodon", slog.String("url", status.URL))
+
+	return nil
+}
+
+// mastodonText adds a link to the full report on Telegram and the hashtags to the report. Unlike Bluesky, Mastodon
+// has no separate tags, so the hashtags go in the text.
+func mastodonText(report, url string) string {
+	hashtags := make([]string, 0, len(internal.Tags))
+	for _, tag := range internal.Tags {
+		hashtags = append(hashtags, "#"+tag)
+	}
+
+	return fmt.Sprintf("%s\n\n👉 Bekijk het volledige energiebericht op Telegram: %s\n\n%s", report, url, strings.Join(hashtags, " "))
+}
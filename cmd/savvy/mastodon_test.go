@@ -0,0 +1,44 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/heyajulia/savvy/internal/config"
+)
+
+func TestPostToMastodon(t *testing.T) {
+	var got http.Header
+	var status string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header
+		status = r.FormValue("status")
+
+		_, _ = w.Write([]byte(`{"id":"1","url":"https://mastodon.example/@energieprijzen/1"}`))
+	}))
+	defer ts.Close()
+
+	cfg := config.Mastodon{Server: ts.URL, Token: "secret", Visibility: "public"}
+
+	if err := postToMastodon("zaterdag 15 maart 2025", cfg, "https://t.me/energieprijzen/123"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Get("Authorization") != "Bearer secret" {
+		t.Errorf("got authorization %q", got.Get("Authorization"))
+	}
+
+	want := "zaterdag 15 maart 2025\n\n👉 Bekijk het volledige energiebericht op Telegram: https://t.me/energieprijzen/123\n\n#energie #energiebericht"
+	if !strings.HasPrefix(status, want) {
+		t.Errorf("got status %q, want it to start with %q", status, want)
+	}
+}
+
+func TestPostToMastodonDisabled(t *testing.T) {
+	if err := postToMastodon("zaterdag 15 maart 2025", config.Mastodon{}, "https://t.me/energieprijzen/123"); err != nil {
+		t.Errorf("got %v, want nothing to happen", err)
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/heyajulia/savvy/internal"
+	"github.com/heyajulia/savvy/internal/config"
+	"github.com/heyajulia/savvy/internal/datetime"
+	"github.com/heyajulia/savvy/internal/history"
+	"github.com/heyajulia/savvy/internal/prices"
+	"github.com/urfave/cli/v3"
+)
+
+func previewCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "preview",
+		Usage: "Print the daily report without posting it",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "date",
+				Usage: "Day to report on (YYYY-MM-DD, default: tomorrow)",
+			},
+			&cli.StringFlag{
+				Name:  "prices",
+				Usage: "Read the prices from a JSON file in the history's format, instead of from the history or the price source",
+			},
+		},
+		Action: runPreview,
+	}
+}
+
+func runPreview(ctx context.Context, c *cli.Command) error {
+	slog.SetDefault(internal.Logger())
+
+	cfg, err := config.Read[config.Preview]()
+	if err != nil {
+		slog.Error("configuration error", slog.Any("err", err))
+		os.Exit(1)
+	}
+
+	sources, err := newSources(cfg.Source)
+	if err != nil {
+		slog.Error("configuration error", slog.Any("err", err))
+		os.Exit(1)
+	}
+
+	opts, err := newReportOptions(cfg.ReportOptions, newHistory(cfg.HistoryDir))
+	if err != nil {
+		return fmt.Errorf("get report options: %w", err)
+	}
+
+	var p *prices.Prices
+
+	if path := c.String("prices"); path != "" {
+		if p, err = history.ReadFile(path); err != nil {
+			return err
+		}
+	} else {
+		day := datetime.Tomorrow(datetime.Now())
+		if s := c.String("date"); s != "" {
+			if day, err = parseDay(s); err != nil {
+				return fmt.Errorf("parse --date: %w", err)
+			}
+		}
+
+		if p, err = dayPrices(ctx, sources, cfg.Tariff, opts.History, day); err != nil {
+			return err
+		}
+	}
+
+	// newTemplateData only reads from the history, so nothing is stored either.
+	data, err := newTemplateData(p, datetime.StartOfDay(p.Start()), opts)
+	if err != nil {
+		return fmt.Errorf("get template data: %w", err)
+	}
+
+	return preview(os.Stdout, *data)
+}
+
+// preview writes everything that the report would post to w: the short report for Bluesky, the long report for
+// Telegram, the chart's alt text and the alert, if any.
+func preview(w io.Writer, data templateData) error {
+	short, long, err := report(data)
+	if err != nil {
+		return fmt.Errorf("get reports: %w", err)
+	}
+
+	fmt.Fprintf(w, "--- Bluesky ---\n%s\n\n--- Telegram ---\n%s\n", short, long)
+
+	if data.Chart != nil {
+		fmt.Fprintf(w, "\n--- Chart ---\n%s\n", data.ChartAlt)
+	}
+
+	if data.Alert != nil {
+		short, long, err := alert(*data.Alert)
+		if err != nil {
+			return fmt.Errorf("get alerts: %w", err)
+		}
+
+		fmt.Fprintf(w, "\n--- Alert on Bluesky ---\n%s\n\n--- Alert on Telegram ---\n%s\n", short, long)
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/heyajulia/savvy/internal/datetime"
+	"github.com/heyajulia/savvy/internal/history"
+	"github.com/heyajulia/savvy/internal/prices"
+	"github.com/heyajulia/savvy/internal/prices/pricestest"
+)
+
+func TestPreview(t *testing.T) {
+	loc := datetime.Location()
+
+	tomorrow := time.Date(2025, time.March, 15, 0, 0, 0, 0, loc)
+
+	ps := pricestest.Constant(24, 0.1)
+	ps[13] = -0.05
+
+	h := history.New(t.TempDir())
+
+	data, err := newTemplateData(prices.New(tomorrow, time.Hour, ps, prices.Wholesale), tomorrow, reportOptions{History: h})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sb strings.Builder
+	if err := preview(&sb, *data); err != nil {
+		t.Fatal(err)
+	}
+
+	got := sb.String()
+
+	for _, want := range []string{"--- Bluesky ---\nzaterdag 15 maart 2025", "--- Telegram ---\nEnergieprijzen zaterdag 15 maart 2025", "--- Chart ---\nStaafdiagram", "--- Alert on Telegram ---"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected the preview to contain %q, got:\n%s", want, got)
+		}
+	}
+
+	// A preview doesn't store anything.
+	if days, err := h.Days(); err != nil || len(days) != 0 {
+		t.Errorf("expected an empty history, got %v (%v)", days, err)
+	}
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/heyajulia/savvy/internal"
+	"github.com/heyajulia/savvy/internal/config"
+	"github.com/heyajulia/savvy/internal/datetime"
+	"github.com/heyajulia/savvy/internal/prices"
+	"github.com/urfave/cli/v3"
+)
+
+// priceListVersion is the version of the JSON schema of priceList. It changes only when a change would break existing
+// readers, such as removing or renaming a field; new fields may be added without changing it.
+const priceListVersion = 1
+
+func pricesCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "prices",
+		Usage: "Print a day's prices as JSON, CSV or a table",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "date",
+				Usage: "Day to print (YYYY-MM-DD, default: tomorrow)",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "Output format: json, csv or table",
+				Value: "table",
+			},
+		},
+		Action: runPrices,
+	}
+}
+
+func runPrices(ctx context.Context, c *cli.Command) error {
+	slog.SetDefault(internal.Logger())
+
+	cfg, err := config.Read[config.Prices]()
+	if err != nil {
+		slog.Error("configuration error", slog.Any("err", err))
+		os.Exit(1)
+	}
+
+	sources, err := newSources(cfg.Source)
+	if err != nil {
+		slog.Error("configuration error", slog.Any("err", err))
+		os.Exit(1)
+	}
+
+	opts, err := newReportOptions(cfg.ReportOptions, newHistory(cfg.HistoryDir))
+	if err != nil {
+		return fmt.Errorf("get report options: %w", err)
+	}
+
+	day := datetime.Tomorrow(datetime.Now())
+	if s := c.String("date"); s != "" {
+		if day, err = parseDay(s); err != nil {
+			return fmt.Errorf("parse --date: %w", err)
+		}
+	}
+
+	p, err := dayPrices(ctx, sources, cfg.Tariff, opts.History, day)
+	if err != nil {
+		return err
+	}
+
+	return writePriceList(os.Stdout, newPriceList(p, opts), c.String("format"))
+}
+
+// priceList is the machine-readable form of a day's prices. Its JSON form is a stable schema, versioned by
+// priceListVersion. Prices are in euros per kWh.
+type priceList struct {
+	Version    int             `json:"version"`
+	Date       string          `json:"date"`
+	Resolution int             `json:"resolution_minutes"`
+	Wholesale  bool            `json:"wholesale_only"`
+	Average    float64         `json:"average"`
+	High       float64         `json:"high"`
+	Low        float64         `json:"low"`
+	Slots      []priceListSlot `json:"slots"`
+}
+
+type priceListSlot struct {
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	Wholesale float64   `json:"wholesale"`
+	Price     float64   `json:"price"`
+
+	// Tier is one of very_cheap, cheap, normal, expensive and very_expensive.
+	Tier string `json:"tier"`
+
+	// IsHigh, IsLow and IsAverage mark the slots whose price equals the day's high, low and average.
+	IsHigh    bool `json:"is_high"`
+	IsLow     bool `json:"is_low"`
+	IsAverage bool `json:"is_average"`
+}
+
+// newPriceList lists p like the report does: hourly, unless opts asks for quarter hours, and with the same tiers.
+func newPriceList(p *prices.Prices, opts reportOptions) priceList {
+	if !opts.QuarterHours {
+		p = p.Hourly()
+	}
+
+	thresholds := p.PercentileThresholds()
+	if opts.Thresholds != nil {
+		thresholds = *opts.Thresholds
+	}
+
+	list := priceList{
+		Version:    priceListVersion,
+		Date:       p.Start().Format(time.DateOnly),
+		Resolution: int(p.Resolution() / time.Minute),
+		Wholesale:  p.Tariff() == prices.Wholesale,
+		Average:    p.Average(),
+		High:       p.High(),
+		Low:        p.Low(),
+		Slots:      make([]priceListSlot, 0, p.Len()),
+	}
+
+	wholesale := p.Wholesale()
+
+	for i, s := range p.All() {
+		list.Slots = append(list.Slots, priceListSlot{
+			Start:     s.Start,
+			End:       s.End,
+			Wholesale: wholesale[i],
+			Price:     s.Price,
+			Tier:      strings.ReplaceAll(thresholds.Classify(s.Price).String(), " ", "_"),
+			IsHigh:    s.Price == p.High(),
+			IsLow:     s.Price == p.Low(),
+			IsAverage: s.Price == p.Average(),
+		})
+	}
+
+	return list
+}
+
+func writePriceList(w io.Writer, list priceList, format string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(list)
+	case "csv":
+		cw := csv.NewWriter(w)
+
+		_ = cw.Write([]string{"start", "end", "wholesale", "price", "tier", "is_high", "is_low", "is_average"})
+
+		for _, s := range list.Slots {
+			_ = cw.Write([]string{
+				s.Start.Format(time.RFC3339),
+				s.End.Format(time.RFC3339),
+				strconv.FormatFloat(s.Wholesale, 'f', -1, 64),
+				strconv.FormatFloat(s.Price, 'f', -1, 64),
+				s.Tier,
+				strconv.FormatBool(s.IsHigh),
+				strconv.FormatBool(s.IsLow),
+				strconv.FormatBool(s.IsAverage),
+			})
+		}
+
+		cw.Flush()
+
+		return cw.Error()
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+		fmt.Fprintln(tw, "TIME\tWHOLESALE\tPRICE\tTIER\t")
+
+		for _, s := range list.Slots {
+			var marker string
+			switch {
+			case s.IsHigh:
+				marker = "high"
+			case s.IsLow:
+				marker = "low"
+			case s.IsAverage:
+				marker = "average"
+			}
+
+			fmt.Fprintf(tw, "%s–%s\t%.5f\t%.2f\t%s\t%s\n", s.Start.Format("15:04"), s.End.Format("15:04"), s.Wholesale, s.Price, s.Tier, marker)
+		}
+
+		fmt.Fprintf(tw, "\naverage %.2f, high %.2f, low %.2f (euros per kWh)\n", list.Average, list.High, list.Low)
+
+		return tw.Flush()
+	default:
+		return fmt.Errorf("%w: %q", errUnknownFormat, format)
+	}
+}
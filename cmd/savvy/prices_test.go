@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/heyajulia/savvy/internal/datetime"
+	"github.com/heyajulia/savvy/internal/prices"
+	"github.com/heyajulia/savvy/internal/prices/pricestest"
+)
+
+func testPriceList(t *testing.T) priceList {
+	t.Helper()
+
+	loc := datetime.Location()
+
+	// Quarter-hourly prices are listed hourly by default.
+	ps := pricestest.Constant(96, 0.1)
+	for i := 12; i < 16; i++ {
+		ps[i] = -0.1
+	}
+	for i := 72; i < 76; i++ {
+		ps[i] = 0.3
+	}
+
+	p := prices.New(time.Date(2025, time.March, 15, 0, 0, 0, 0, loc), 15*time.Minute, ps, prices.Wholesale)
+
+	return newPriceList(p, reportOptions{})
+}
+
+func TestPriceListJSON(t *testing.T) {
+	var sb strings.Builder
+	if err := writePriceList(&sb, testPriceList(t), "json"); err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct {
+		Version    int    `json:"version"`
+		Date       string `json:"date"`
+		Resolution int    `json:"resolution_minutes"`
+		Slots      []struct {
+			Start string  `json:"start"`
+			Price float64 `json:"price"`
+			Tier  string  `json:"tier"`
+			High  bool    `json:"is_high"`
+			Low   bool    `json:"is_low"`
+		} `json:"slots"`
+	}
+
+	if err := json.Unmarshal([]byte(sb.String()), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Version != 1 || got.Date != "2025-03-15" || got.Resolution != 60 || len(got.Slots) != 24 {
+		t.Fatalf("got version %d, date %q, resolution %d and %d slots", got.Version, got.Date, got.Resolution, len(got.Slots))
+	}
+
+	low, high := got.Slots[3], got.Slots[18]
+
+	if low.Start != "2025-03-15T03:00:00+01:00" || low.Price != -0.1 || !low.Low || low.Tier != "very_cheap" {
+		t.Errorf("unexpected low slot %+v", low)
+	}
+
+	if high.Price != 0.3 || !high.High || high.Tier != "very_expensive" {
+		t.Errorf("unexpected high slot %+v", high)
+	}
+}
+
+func TestPriceListCSV(t *testing.T) {
+	var sb strings.Builder
+	if err := writePriceList(&sb, testPriceList(t), "csv"); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(sb.String()), "\n")
+
+	if len(lines) != 25 {
+		t.Fatalf("got %d lines, want a header and 24 rows", len(lines))
+	}
+
+	if want := "start,end,wholesale,price,tier,is_high,is_low,is_average"; lines[0] != want {
+		t.Errorf("got header %q, want %q", lines[0], want)
+	}
+
+	if want := "2025-03-15T03:00:00+01:00,2025-03-15T04:00:00+01:00,-0.1,-0.1,very_cheap,false,true,false"; lines[4] != want {
+		t.Errorf("got row %q, want %q", lines[4], want)
+	}
+}
+
+func TestPriceListTable(t *testing.T) {
+	var sb strings.Builder
+	if err := writePriceList(&sb, testPriceList(t), "table"); err != nil {
+		t.Fatal(err)
+	}
+
+	got := sb.String()
+
+	for _, want := range []string{"03:00–04:00", "very_expensive  high", "average 0.10, high 0.30, low -0.10"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected the table to contain %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestPriceListUnknownFormat(t *testing.T) {
+	if err := writePriceList(&strings.Builder{}, testPriceList(t), "xml"); !errors.Is(err, errUnknownFormat) {
+		t.Errorf("got %v, want %v", err, errUnknownFormat)
+	}
+}
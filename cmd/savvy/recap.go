@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+
+	"github.com/heyajulia/savvy/internal/bsky"
+	"github.com/heyajulia/savvy/internal/config"
+	"github.com/heyajulia/savvy/internal/datetime"
+	"github.com/heyajulia/savvy/internal/history"
+	"github.com/heyajulia/savvy/internal/prices"
+	"github.com/heyajulia/savvy/internal/ranges"
+)
+
+type recapData struct {
+	Year             int
+	Days             int
+	ExpectedDays     int
+	AverageFormatted string
+	Highest          recapHour
+	Lowest           recapHour
+	NegativeHours    int
+	Months           []recapLine
+	Hours            []recapLine
+}
+
+// recapHour is a single hour of the year.
+type recapHour struct {
+	Day            string
+	Hours          string
+	FormattedPrice string
+}
+
+// recapLine is a line in a table of average prices.
+type recapLine struct {
+	Name           string
+	FormattedPrice string
+}
+
+// recapHours is half of the table of average prices by hour of the day. A whole table doesn't fit in a single post
+// on Bluesky.
+type recapHours struct {
+	Year  int
+	Hours []recapLine
+}
+
+func postRecap(cfg config.Report, h *history.Store, p period) error {
+	data, err := getRecapData(h, p)
+	if err != nil {
+		return fmt.Errorf("get recap data: %w", err)
+	}
+
+	long, thread, err := recap(*data)
+	if err != nil {
+		return fmt.Errorf("get recaps: %w", err)
+	}
+
+	url, _, err := postToTelegram(long, cfg.Telegram.Token, cfg.Telegram.ChatID, cfg.Telegram.ChannelName)
+	if err != nil {
+		return fmt.Errorf("post recap to telegram: %w", err)
+	}
+
+	client, err := bsky.Login(cfg.Bluesky.Identifier, cfg.Bluesky.Password)
+	if err != nil {
+		return fmt.Errorf("login to bluesky: %w", err)
+	}
+
+	if err := client.PostThread(thread, url); err != nil {
+		return fmt.Errorf("post recap to bluesky: %w", err)
+	}
+
+	// A thread on Mastodon would be much the same, but the introduction is enough to point people to Telegram.
+	if err := postToMastodon(thread[0], cfg.Mastodon, url); err != nil {
+		slog.Error("could not post recap to mastodon", slog.Any("err", err))
+	}
+
+	return nil
+}
+
+func getRecapData(h *history.Store, p period) (*recapData, error) {
+	days, err := h.Range(p.Start, p.End.AddDate(0, 0, -1))
+	if err != nil {
+		return nil, fmt.Errorf("load prices: %w", err)
+	}
+
+	s, err := prices.Summarize(days)
+	if err != nil {
+		return nil, fmt.Errorf("summarise prices of %d: %w", p.Start.Year(), err)
+	}
+
+	data := recapData{
+		Year:             p.Start.Year(),
+		Days:             s.Days,
+		ExpectedDays:     p.Days(),
+		AverageFormatted: prices.Format(s.Average),
+		Highest:          newRecapHour(s.Highest),
+		Lowest:           newRecapHour(s.Lowest),
+		NegativeHours:    s.NegativeHours,
+	}
+
+	// Days are in chronological order, so grouping consecutive days by month keeps the months in order too.
+	var month []*prices.Prices
+
+	flush := func() error {
+		if len(month) == 0 {
+			return nil
+		}
+
+		ms, err := prices.Summarize(month)
+		if err != nil {
+			return err
+		}
+
+		data.Months = append(data.Months, recapLine{Name: datetime.MonthName(month[0].Start()), FormattedPrice: prices.Format(ms.Average)})
+		month = nil
+
+		return nil
+	}
+
+	for _, day := range days {
+		if len(month) > 0 && month[0].Start().Month() != day.Start().Month() {
+			if err := flush(); err != nil {
+				return nil, fmt.Errorf("summarise month: %w", err)
+			}
+		}
+
+		month = append(month, day)
+	}
+
+	if err := flush(); err != nil {
+		return nil, fmt.Errorf("summarise month: %w", err)
+	}
+
+	for hour, average := range s.HourAverages {
+		if math.IsNaN(average) {
+			continue
+		}
+
+		data.Hours = append(data.Hours, recapLine{Name: fmt.Sprintf("%02d:00", hour), FormattedPrice: prices.Format(average)})
+	}
+
+	return &data, nil
+}
+
+func newRecapHour(s prices.Slot) recapHour {
+	return recapHour{
+		Day:            datetime.Format(s.Start),
+		Hours:          ranges.Format([]ranges.Range{ranges.Single(s.Start.Hour())}),
+		FormattedPrice: prices.Format(s.Price),
+	}
+}
+
+// recap renders the recap as a single Telegram message and as a thread of Bluesky posts.
+func recap(data recapData) (long string, thread []string, err error) {
+	var sb strings.Builder
+
+	if err := templates.ExecuteTemplate(&sb, "recap-telegram", data); err != nil {
+		return "", nil, fmt.Errorf("render telegram recap: %w", err)
+	}
+
+	long = sb.String()
+
+	half := len(data.Hours) / 2
+
+	posts := []struct {
+		name string
+		data any
+	}{
+		{"recap-intro", data},
+		{"recap-months", data},
+		{"recap-hours", recapHours{Year: data.Year, Hours: data.Hours[:half]}},
+		{"recap-hours", recapHours{Year: data.Year, Hours: data.Hours[half:]}},
+	}
+
+	for _, post := range posts {
+		sb.Reset()
+
+		if err := templates.ExecuteTemplate(&sb, post.name, post.data); err != nil {
+			return "", nil, fmt.Errorf("render %s: %w", post.name, err)
+		}
+
+		thread = append(thread, sb.String())
+	}
+
+	return long, thread, nil
+}
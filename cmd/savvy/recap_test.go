@@ -0,0 +1,101 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/heyajulia/savvy/internal/datetime"
+	"github.com/heyajulia/savvy/internal/history"
+	"github.com/heyajulia/savvy/internal/prices"
+	"github.com/heyajulia/savvy/internal/prices/pricestest"
+)
+
+func TestGetRecapData(t *testing.T) {
+	loc := datetime.Location()
+
+	h := history.New(t.TempDir())
+
+	// Two days in January and one in March, each with a cheap night and an expensive evening.
+	days := []struct {
+		day   time.Time
+		price float64
+	}{
+		{time.Date(2024, time.January, 1, 0, 0, 0, 0, loc), 0.2},
+		{time.Date(2024, time.January, 2, 0, 0, 0, 0, loc), 0.3},
+		{time.Date(2024, time.March, 31, 0, 0, 0, 0, loc), 0.1},
+	}
+
+	for _, d := range days {
+		// 31 March 2024 is the day DST starts, which only has 23 hours.
+		n := int(d.day.AddDate(0, 0, 1).Sub(d.day) / time.Hour)
+
+		ps := pricestest.Constant(n, d.price)
+		ps[3] = -0.05
+		ps[n-5] = d.price * 2
+
+		if err := h.Save(prices.New(d.day, time.Hour, ps, prices.Wholesale)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p, err := lastPeriod("year", time.Date(2025, time.January, 1, 9, 0, 0, 0, loc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := getRecapData(h, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if data.Year != 2024 || data.Days != 3 || data.ExpectedDays != 366 {
+		t.Errorf("got year %d with %d of %d days", data.Year, data.Days, data.ExpectedDays)
+	}
+
+	if want := (recapHour{Day: "dinsdag 2 januari 2024", Hours: "van 19:00 tot 19:59", FormattedPrice: "€\u00a00,60"}); data.Highest != want {
+		t.Errorf("got highest %+v, want %+v", data.Highest, want)
+	}
+
+	if want := (recapHour{Day: "maandag 1 januari 2024", Hours: "van 03:00 tot 03:59", FormattedPrice: "€\u00a0-0,05"}); data.Lowest != want {
+		t.Errorf("got lowest %+v, want %+v", data.Lowest, want)
+	}
+
+	if data.NegativeHours != 3 {
+		t.Errorf("got %d negative hours, want 3", data.NegativeHours)
+	}
+
+	var months []string
+	for _, m := range data.Months {
+		months = append(months, m.Name)
+	}
+
+	if got := strings.Join(months, ","); got != "januari,maart" {
+		t.Errorf("got months %s, want januari,maart", got)
+	}
+
+	if len(data.Hours) != 24 {
+		t.Errorf("got %d hours, want 24", len(data.Hours))
+	}
+
+	long, thread, err := recap(*data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(long, "Jaaroverzicht 2024") || !strings.Contains(long, "3 van de 366 dagen") {
+		t.Errorf("unexpected telegram recap %q", long)
+	}
+
+	if len(thread) != 4 {
+		t.Fatalf("got %d posts, want 4", len(thread))
+	}
+
+	// Bluesky allows 300 graphemes per post. The first post also gets a link to Telegram.
+	for i, post := range thread {
+		if n := utf8.RuneCountInString(post); n > 250 {
+			t.Errorf("post %d is %d characters long:\n%s", i, n, post)
+		}
+	}
+}
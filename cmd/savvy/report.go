@@ -1,9 +1,12 @@
 package main
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"os"
 	"slices"
 	"strings"
@@ -11,9 +14,11 @@ import (
 
 	"github.com/heyajulia/savvy/internal"
 	"github.com/heyajulia/savvy/internal/bsky"
+	"github.com/heyajulia/savvy/internal/chart"
 	"github.com/heyajulia/savvy/internal/config"
 	"github.com/heyajulia/savvy/internal/cronitor"
 	"github.com/heyajulia/savvy/internal/datetime"
+	"github.com/heyajulia/savvy/internal/history"
 	"github.com/heyajulia/savvy/internal/prices"
 	"github.com/heyajulia/savvy/internal/ranges"
 	"github.com/heyajulia/savvy/internal/stamp"
@@ -25,8 +30,15 @@ import (
 
 func reportCommand() *cli.Command {
 	return &cli.Command{
-		Name:   "report",
-		Usage:  "Generate and send the daily energy price report",
+		Name:  "report",
+		Usage: "Generate and send the daily energy price report, or a weekly, monthly or yearly summary",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "period",
+				Usage: "Report on tomorrow (day), or summarise last week (week), last month (month) or last year (year)",
+				Value: "day",
+			},
+		},
 		Action: runReport,
 	}
 }
@@ -34,6 +46,13 @@ func reportCommand() *cli.Command {
 func runReport(ctx context.Context, c *cli.Command) error {
 	slog.SetDefault(internal.Logger())
 
+	period := c.String("period")
+	if period != "day" {
+		if _, err := lastPeriod(period, datetime.Now()); err != nil {
+			return err
+		}
+	}
+
 	slog.Info("application info", slog.Group("app", slog.String("version", internal.Version), slog.String("commit", internal.Commit)))
 
 	cfg, err := config.Read[config.Report]()
@@ -42,19 +61,34 @@ func runReport(ctx context.Context, c *cli.Command) error {
 		os.Exit(1)
 	}
 
-	token := cfg.Telegram.Token
-	chatID := cfg.Telegram.ChatID
-	channelName := cfg.Telegram.ChannelName
-	blueskyIdentifier := cfg.Bluesky.Identifier
-	blueskyPassword := cfg.Bluesky.Password
-	cronitorURL := cfg.Cronitor.URL
-	stampDir := cfg.StampDir
+	sources, err := newSources(cfg.Source)
+	if err != nil {
+		slog.Error("configuration error", slog.Any("err", err))
+		os.Exit(1)
+	}
 
-	slog.Info("posting energy report")
+	opts, err := newReportOptions(cfg.ReportOptions, newHistory(cfg.HistoryDir))
+	if err != nil {
+		slog.Error("configuration error", slog.Any("err", err))
+		os.Exit(1)
+	}
 
-	monitor := cronitor.New(cronitorURL)
+	if taxRatesRunOut(cfg.Tariff, datetime.Tomorrow(datetime.Now())) {
+		slog.Warn("the built-in tax rates run out soon; add the new rates or set TF_ENERGY_TAX",
+			slog.String("until", prices.TaxRatesUntil().Format(time.DateOnly)))
+	}
+
+	monitor := cronitor.New(monitorURL(cfg.Cronitor, period))
 	if err := monitor.Monitor(func() error {
-		return post(token, chatID, channelName, blueskyIdentifier, blueskyPassword, stampDir)
+		if period != "day" {
+			slog.Info("posting summary", slog.String("period", period))
+
+			return postSummary(ctx, cfg, sources, period)
+		}
+
+		slog.Info("posting energy report", slog.String("source", cfg.Source.Name), slog.String("secondary_source", cfg.Source.Secondary))
+
+		return post(ctx, cfg, sources, opts)
 	}); err != nil {
 		slog.Error("failed to post", slog.Any("err", err))
 	}
@@ -62,8 +96,18 @@ func runReport(ctx context.Context, c *cli.Command) error {
 	return nil
 }
 
-func post(token string, chatID chatid.ChatID, channelName, blueskyIdentifier, blueskyPassword, stampDir string) error {
-	s := stamp.New(stampDir)
+// monitorURL returns the URL of the Cronitor monitor for the given period. Summaries have their own monitor, so that
+// they don't show up as unexpected runs (or failures) of the daily report.
+func monitorURL(cfg config.Cronitor, period string) string {
+	if period == "day" {
+		return cfg.URL
+	}
+
+	return cfg.SummaryURL
+}
+
+func post(ctx context.Context, cfg config.Report, sources internal.Sources, opts reportOptions) error {
+	s := stamp.New(cfg.StampDir)
 
 	exists, err := s.Exists()
 	if err != nil {
@@ -75,7 +119,14 @@ func post(token string, chatID chatid.ChatID, channelName, blueskyIdentifier, bl
 		return nil
 	}
 
-	data, err := getTemplateData()
+	tomorrow := datetime.Tomorrow(datetime.Now())
+
+	tariff, err := tariffOn(cfg.Tariff, tomorrow)
+	if err != nil {
+		return fmt.Errorf("get tariff: %w", err)
+	}
+
+	data, err := getTemplateData(ctx, sources, tariff, tomorrow, opts)
 	if err != nil {
 		return fmt.Errorf("get template data: %w", err)
 	}
@@ -85,15 +136,44 @@ func post(token string, chatID chatid.ChatID, channelName, blueskyIdentifier, bl
 		return fmt.Errorf("get reports: %w", err)
 	}
 
-	url, err := postToTelegram(long, token, chatID, channelName)
+	url, messageID, err := postToTelegram(long, cfg.Telegram.Token, cfg.Telegram.ChatID, cfg.Telegram.ChannelName)
 	if err != nil {
 		return fmt.Errorf("post report to telegram: %w", err)
 	}
 
-	if err := postToBluesky(short, blueskyIdentifier, blueskyPassword, url); err != nil {
+	var images []bsky.Image
+
+	if data.Chart != nil {
+		// The report is already out, so the chart is a nice-to-have.
+		if err := postChartToTelegram(data.Chart, data.ChartAlt, cfg.Telegram.Token, cfg.Telegram.ChatID, messageID); err != nil {
+			slog.Warn("could not post chart to telegram", slog.Any("err", err))
+		}
+
+		images = append(images, bsky.Image{Data: data.Chart, Alt: data.ChartAlt})
+	}
+
+	if err := postToBluesky(short, cfg.Bluesky.Identifier, cfg.Bluesky.Password, url, images...); err != nil {
 		return fmt.Errorf("post report to bluesky: %w", err)
 	}
 
+	// Mastodon comes last, so failing here would only cause the report to be posted again everywhere else.
+	if err := postToMastodon(short, cfg.Mastodon, url); err != nil {
+		slog.Error("could not post report to mastodon", slog.Any("err", err))
+	}
+
+	if cfg.FeedFile != "" {
+		if err := appendToFeed(cfg.FeedFile, cfg.FeedEntries, cfg.Telegram.ChannelName, data.TomorrowDate, long, url, time.Now()); err != nil {
+			slog.Error("could not add report to feed", slog.Any("err", err))
+		}
+	}
+
+	// The report itself has been posted by now, so failing here would only cause it to be posted again.
+	if data.Alert != nil {
+		if err := postAlert(*data.Alert, cfg); err != nil {
+			slog.Error("could not post negative price alert", slog.Any("err", err))
+		}
+	}
+
 	if err := s.Stamp(); err != nil {
 		return fmt.Errorf("create stamp: %w", err)
 	}
@@ -105,77 +185,351 @@ func post(token string, chatID chatid.ChatID, channelName, blueskyIdentifier, bl
 	return nil
 }
 
-func postToBluesky(report, username, password, url string) error {
+func postToBluesky(report, username, password, url string, images ...bsky.Image) error {
 	client, err := bsky.Login(username, password)
 	if err != nil {
 		return fmt.Errorf("login to bluesky: %w", err)
 	}
 
-	if err := client.Post(report, url); err != nil {
+	if err := client.Post(report, url, images...); err != nil {
 		return fmt.Errorf("post to bluesky: %w", err)
 	}
 
 	return nil
 }
 
+// cheapestHours is the number of cheapest hours that the report lists.
+const cheapestHours = 6
+
 type templateData struct {
 	Short            bool
+	QuarterHours     bool
+	Wholesale        bool
 	TomorrowDate     string
 	AverageFormatted string
 	HighFormatted    string
 	HighHours        string
 	LowFormatted     string
 	LowHours         string
-	Hourly           []hourly
+	MedianFormatted  string
+	P10Formatted     string
+	P90Formatted     string
+	StdDevFormatted  string
+	SpreadFormatted  string
+	CheapestHours    string
+	Tiers            []tier
+	Slots            []slot
+
+	// CheapestCount is the number of hours that CheapestHours lists. That is one fewer than cheapestHours when both 02:00
+	// hours are among the cheapest on the day DST ends, because CheapestHours lists 02:00 only once.
+	CheapestCount int
+
+	// ComparedToday and ComparedLastWeek are nil when there are no prices to compare with.
+	ComparedToday    *comparison
+	ComparedLastWeek *comparison
+
+	// Alert is non-nil when electricity is free or wholesale prices are negative at some point tomorrow.
+	Alert *alertData
+
+	// Chart is a PNG bar chart of tomorrow's hourly prices, or nil if it couldn't be drawn. ChartAlt describes it, and is
+	// empty when there is no chart.
+	Chart    []byte
+	ChartAlt string
+}
+
+type alertData struct {
+	Short        bool
+	TomorrowDate string
+
+	// FreePeriods is when electricity is free or better after charges, and NegativePeriods is when only the wholesale
+	// price is negative. Either may be empty, but not both.
+	FreePeriods     string
+	NegativePeriods string
+
+	LowestFormatted string
+}
+
+// comparison describes how tomorrow's prices compare with those of another day, in phrases such as "12% goedkoper dan
+// vandaag".
+type comparison struct {
+	Average string
+	High    string
+	Low     string
 }
 
-type hourly struct {
+type tier struct {
+	Emoji string
+	Label string
+}
+
+type slot struct {
 	Emoji          string
-	PaddedHour     string
+	Start          string
+	End            string
 	FormattedPrice string
 }
 
-func getTemplateData() (*templateData, error) {
-	p, err := internal.GetEnergyPrices()
+// reportOptions determine what the report looks like.
+type reportOptions struct {
+	QuarterHours bool
+
+	// Thresholds classify prices into tiers. When nil, the tiers are based on the day's percentiles.
+	Thresholds *prices.Thresholds
+
+	// History stores the fetched prices, and provides the prices to compare them with. When nil, prices are neither
+	// stored nor compared.
+	History *history.Store
+}
+
+func newReportOptions(cfg config.ReportOptions, h *history.Store) (reportOptions, error) {
+	opts := reportOptions{QuarterHours: cfg.QuarterHours, History: h}
+
+	if len(cfg.Tiers) > 0 {
+		thresholds, err := prices.NewThresholds(cfg.Tiers)
+		if err != nil {
+			return reportOptions{}, err
+		}
+
+		opts.Thresholds = &thresholds
+	}
+
+	return opts, nil
+}
+
+func getTemplateData(ctx context.Context, sources internal.Sources, tariff prices.Tariff, tomorrow time.Time, opts reportOptions) (*templateData, error) {
+	p, err := internal.GetEnergyPrices(ctx, sources, tariff, tomorrow)
 	if err != nil {
 		return nil, fmt.Errorf("get energy prices: %w", err)
 	}
 
-	now := datetime.Now()
-	tomorrow := datetime.Tomorrow(now)
-	hourlyHours := hourNumbersForDay(tomorrow, p.Len())
+	if opts.History != nil {
+		if err := opts.History.Save(p); err != nil {
+			slog.Warn("could not save prices to history", slog.Any("err", err))
+		}
+	}
 
-	average := p.Average()
-	hourlies := make([]hourly, 0, len(hourlyHours))
+	return newTemplateData(p, tomorrow, opts)
+}
 
-	for hour, price := range p.All() {
-		if hour >= len(hourlyHours) {
-			break
-		}
+// newTemplateData describes p, the prices of tomorrow, for the report templates. Unlike getTemplateData, it doesn't
+// fetch or store anything, though it reads from opts.History to compare p with earlier days.
+func newTemplateData(p *prices.Prices, tomorrow time.Time, opts reportOptions) (*templateData, error) {
+	var comparedToday, comparedLastWeek *comparison
+
+	if opts.History != nil {
+		comparedToday = compareWith(opts.History, p, tomorrow.AddDate(0, 0, -1), "vandaag")
+		comparedLastWeek = compareWith(opts.History, p, tomorrow.AddDate(0, 0, -7), "vorige week "+datetime.Weekday(tomorrow))
+	}
+
+	// The cheapest hours are always whole hours, even when the report lists quarter hours.
+	cheapest, err := p.Hourly().Cheapest(cheapestHours, prices.CheapestOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("select cheapest hours: %w", err)
+	}
+
+	// Like the cheapest hours, the chart always shows whole hours; 96 bars would be too narrow to read.
+	var chartAlt string
+
+	png, err := chart.PNG(p.Hourly())
+	if err != nil {
+		slog.Warn("could not draw chart", slog.Any("err", err))
+	} else {
+		chartAlt = chart.AltText(p.Hourly())
+	}
+
+	// Look for free and negative prices before averaging, which could hide a single negative quarter hour. The periods are
+	// listed in whole hours, like the rest of the report, unless the report shows quarter hours.
+	alertResolution := time.Hour
+	if opts.QuarterHours {
+		alertResolution = p.Resolution()
+	}
 
-		actualHour := hourlyHours[hour]
+	alert := getAlertData(p, tomorrow, alertResolution)
 
-		hourlies = append(hourlies, hourly{
-			Emoji:          internal.GetPriceEmoji(price, average),
-			PaddedHour:     fmt.Sprintf("%02d", actualHour),
-			FormattedPrice: prices.Format(price),
+	if !opts.QuarterHours {
+		p = p.Hourly()
+	}
+
+	thresholds := p.PercentileThresholds()
+	if opts.Thresholds != nil {
+		thresholds = *opts.Thresholds
+	}
+
+	tiers := make([]tier, 0, len(prices.Tiers()))
+	for _, t := range prices.Tiers() {
+		tiers = append(tiers, tier{Emoji: t.Emoji(), Label: t.Label()})
+	}
+
+	resolution := p.Resolution()
+	slots := make([]slot, 0, p.Len())
+
+	for _, s := range p.All() {
+		slots = append(slots, slot{
+			Emoji:          internal.GetPriceEmoji(s.Price, thresholds.Classify(s.Price)),
+			Start:          s.Start.Format("15:04"),
+			End:            s.End.Add(-time.Minute).Format("15:04"),
+			FormattedPrice: prices.Format(s.Price),
 		})
 	}
 
 	data := templateData{
 		Short:            false,
+		QuarterHours:     resolution < time.Hour,
+		Wholesale:        p.Tariff() == prices.Wholesale,
 		TomorrowDate:     datetime.Format(tomorrow),
-		AverageFormatted: prices.Format(average),
+		AverageFormatted: prices.Format(p.Average()),
 		HighFormatted:    prices.Format(p.High()),
-		HighHours:        formatHourRanges(p.HighHours(), hourlyHours),
+		HighHours:        formatSlotRanges(p.HighHours(), resolution),
 		LowFormatted:     prices.Format(p.Low()),
-		LowHours:         formatHourRanges(p.LowHours(), hourlyHours),
-		Hourly:           hourlies,
+		LowHours:         formatSlotRanges(p.LowHours(), resolution),
+		MedianFormatted:  prices.Format(p.Median()),
+		P10Formatted:     prices.Format(p.Percentile(10)),
+		P90Formatted:     prices.Format(p.Percentile(90)),
+		StdDevFormatted:  prices.Format(p.StdDev()),
+		SpreadFormatted:  prices.Format(p.Spread()),
+		CheapestCount:    len(slotNumbers(cheapest, time.Hour)),
+		CheapestHours:    formatSlotRanges(cheapest, time.Hour),
+		Tiers:            tiers,
+		Slots:            slots,
+		ComparedToday:    comparedToday,
+		ComparedLastWeek: comparedLastWeek,
+		Alert:            alert,
+		Chart:            png,
+		ChartAlt:         chartAlt,
 	}
 
 	return &data, nil
 }
 
+// compareWith compares p with the prices of the given day in the history. It returns nil if that day isn't in the
+// history.
+func compareWith(h *history.Store, p *prices.Prices, day time.Time, reference string) *comparison {
+	before, err := h.Load(day)
+	if err != nil {
+		if !errors.Is(err, history.ErrNotFound) {
+			slog.Warn("could not load prices from history", slog.Any("err", err))
+		}
+
+		return nil
+	}
+
+	// Compare hourly prices, so that a day with quarter-hourly prices can be compared with one with hourly prices.
+	now, before := p.Hourly(), before.Hourly()
+
+	return &comparison{
+		Average: formatChange(now.Average(), before.Average(), reference),
+		High:    formatChange(now.High(), before.High(), reference),
+		Low:     formatChange(now.Low(), before.Low(), reference),
+	}
+}
+
+// formatChange describes how price compares with before, relative to before when that makes sense.
+func formatChange(price, before float64, reference string) string {
+	diff := price - before
+
+	var amount string
+
+	// A percentage of a price close to or below zero is meaningless.
+	if before >= 0.01 {
+		percent := math.Round(math.Abs(diff) / before * 100)
+		if percent == 0 {
+			return "even duur als " + reference
+		}
+
+		amount = fmt.Sprintf("%.0f%%", percent)
+	} else {
+		if prices.Format(price) == prices.Format(before) {
+			return "even duur als " + reference
+		}
+
+		amount = prices.Format(math.Abs(diff))
+	}
+
+	if diff < 0 {
+		return fmt.Sprintf("%s goedkoper dan %s", amount, reference)
+	}
+
+	return fmt.Sprintf("%s duurder dan %s", amount, reference)
+}
+
+// getAlertData returns the alert for p, or nil if electricity is never free and wholesale prices are never negative.
+// The periods are formatted at the given resolution, which may be coarser than p's.
+func getAlertData(p *prices.Prices, tomorrow time.Time, resolution time.Duration) *alertData {
+	periods := p.NegativePeriods()
+	if len(periods) == 0 {
+		return nil
+	}
+
+	var all, free, negative []prices.Slot
+	for _, w := range periods {
+		for _, s := range w.Slots {
+			// A negative wholesale price doesn't make electricity free when the charges are higher.
+			if s.Price <= 0 {
+				free = append(free, s)
+			} else {
+				negative = append(negative, s)
+			}
+
+			all = append(all, s)
+		}
+	}
+
+	lowest := slices.MinFunc(all, func(a, b prices.Slot) int {
+		return cmp.Compare(a.Price, b.Price)
+	}).Price
+
+	return &alertData{
+		TomorrowDate:    datetime.Format(tomorrow),
+		FreePeriods:     formatSlotRanges(free, resolution),
+		NegativePeriods: formatSlotRanges(negative, resolution),
+		LowestFormatted: prices.Format(lowest),
+	}
+}
+
+func postAlert(data alertData, cfg config.Report) error {
+	short, long, err := alert(data)
+	if err != nil {
+		return fmt.Errorf("get alerts: %w", err)
+	}
+
+	url, _, err := postToTelegram(long, cfg.Telegram.Token, cfg.Telegram.ChatID, cfg.Telegram.ChannelName)
+	if err != nil {
+		return fmt.Errorf("post alert to telegram: %w", err)
+	}
+
+	if err := postToBluesky(short, cfg.Bluesky.Identifier, cfg.Bluesky.Password, url); err != nil {
+		return fmt.Errorf("post alert to bluesky: %w", err)
+	}
+
+	// Like the report, the alert is already out by now, so a failure here is only logged.
+	if err := postToMastodon(short, cfg.Mastodon, url); err != nil {
+		slog.Error("could not post alert to mastodon", slog.Any("err", err))
+	}
+
+	return nil
+}
+
+func alert(data alertData) (short, long string, err error) {
+	var sb strings.Builder
+
+	data.Short = true
+	if err := templates.ExecuteTemplate(&sb, "alert.tmpl", data); err != nil {
+		return "", "", fmt.Errorf("render short alert: %w", err)
+	}
+
+	short = sb.String()
+	sb.Reset()
+
+	data.Short = false
+	if err := templates.ExecuteTemplate(&sb, "alert.tmpl", data); err != nil {
+		return "", "", fmt.Errorf("render long alert: %w", err)
+	}
+
+	long = sb.String()
+
+	return
+}
+
 func report(data templateData) (short, long string, err error) {
 	var sb strings.Builder
 
@@ -197,65 +551,54 @@ func report(data templateData) (short, long string, err error) {
 	return
 }
 
-func formatHourRanges(indexes, hours []int) string {
-	if len(indexes) == 0 || len(hours) == 0 {
+// formatSlotRanges formats the given slots as ranges of wall-clock times.
+func formatSlotRanges(slots []prices.Slot, resolution time.Duration) string {
+	if len(slots) == 0 {
 		return ""
 	}
 
-	unique := make(map[int]struct{}, len(indexes))
-	dedup := make([]int, 0, len(indexes))
-
-	for _, idx := range indexes {
-		if idx < 0 || idx >= len(hours) {
-			continue
-		}
-
-		hour := hours[idx]
+	numbers := slotNumbers(slots, resolution)
 
-		if _, ok := unique[hour]; ok {
-			continue
-		}
-
-		unique[hour] = struct{}{}
-		dedup = append(dedup, hour)
-	}
-
-	if len(dedup) == 0 {
-		return ""
+	if resolution < time.Hour {
+		return ranges.CollapseAndFormatQuarters(numbers)
 	}
 
-	slices.Sort(dedup)
-
-	return ranges.CollapseAndFormat(dedup)
+	return ranges.CollapseAndFormat(numbers)
 }
 
-func hourNumbersForDay(day time.Time, count int) []int {
-	if count <= 0 {
-		return nil
-	}
+// slotNumbers numbers the slots by their wall-clock start within the day, at the given resolution, in order and without
+// duplicates.
+func slotNumbers(slots []prices.Slot, resolution time.Duration) []int {
+	minutes := int(resolution / time.Minute)
+	unique := make(map[int]struct{}, len(slots))
+	dedup := make([]int, 0, len(slots))
 
-	loc := day.Location()
-	startOfDay := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
-	startOfDayUTC := startOfDay.UTC()
+	for _, s := range slots {
+		// On the day DST ends, two slots start at the same wall-clock time.
+		n := (s.Start.Hour()*60 + s.Start.Minute()) / minutes
 
-	hours := make([]int, 0, count)
+		if _, ok := unique[n]; ok {
+			continue
+		}
 
-	for i := range count {
-		slot := startOfDayUTC.Add(time.Duration(i) * time.Hour).In(loc)
-		hours = append(hours, slot.Hour())
+		unique[n] = struct{}{}
+		dedup = append(dedup, n)
 	}
 
-	return hours
+	slices.Sort(dedup)
+
+	return dedup
 }
 
-func postToTelegram(report, token string, chatID chatid.ChatID, channelName string) (string, error) {
+// postToTelegram sends the report, and returns the URL and ID of the message.
+func postToTelegram(report, token string, chatID chatid.ChatID, channelName string) (string, int64, error) {
 	slog.Info("sending message", slog.String("chat_id", chatID.String()), slog.String("message", report))
 
 	bot := telegram.NewClient(token)
 
 	message, err := bot.SendMessage(chatID, report, option.ParseModeHTML)
 	if err != nil {
-		return "", fmt.Errorf("send message: %w", err)
+		return "", 0, fmt.Errorf("send message: %w", err)
 	}
 
 	messageID := int64(message.ID)
@@ -270,5 +613,17 @@ func postToTelegram(report, token string, chatID chatid.ChatID, channelName stri
 		idLogger.Info("message reacted to")
 	}
 
-	return fmt.Sprintf("https://t.me/%s/%d", channelName, messageID), nil
+	return fmt.Sprintf("https://t.me/%s/%d", channelName, messageID), messageID, nil
+}
+
+// postChartToTelegram sends the chart as a reply to the message with the given ID. The report is too long to be the
+// chart's caption.
+func postChartToTelegram(png []byte, caption, token string, chatID chatid.ChatID, replyTo int64) error {
+	slog.Info("sending chart", slog.String("chat_id", chatID.String()), slog.Int64("reply_to", replyTo))
+
+	if _, err := telegram.NewClient(token).SendPhoto(chatID, png, caption, option.ReplyTo(replyTo)); err != nil {
+		return fmt.Errorf("send photo: %w", err)
+	}
+
+	return nil
 }
@@ -1,86 +1,508 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"slices"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/heyajulia/savvy/internal"
+	"github.com/heyajulia/savvy/internal/config"
+	"github.com/heyajulia/savvy/internal/datetime"
+	"github.com/heyajulia/savvy/internal/history"
+	"github.com/heyajulia/savvy/internal/prices"
+	"github.com/heyajulia/savvy/internal/prices/pricestest"
 )
 
-func TestHourNumbersForDay(t *testing.T) {
-	loc, err := time.LoadLocation("Europe/Amsterdam")
-	if err != nil {
-		t.Fatalf("load location: %v", err)
-	}
+type fakeSource struct {
+	prices []float64
+	err    error
+}
+
+func (f fakeSource) Fetch(ctx context.Context, day time.Time) ([]float64, error) {
+	return slices.Clone(f.prices), f.err
+}
+
+func TestFormatSlotRanges(t *testing.T) {
+	loc := datetime.Location()
+
+	dstStart := time.Date(2025, time.March, 30, 0, 0, 0, 0, loc)
+	dstEnd := time.Date(2024, time.October, 27, 0, 0, 0, 0, loc)
+	regular := time.Date(2024, time.March, 15, 0, 0, 0, 0, loc)
 
-	testCases := []struct {
-		name     string
-		day      time.Time
-		count    int
-		expected []int
+	tests := []struct {
+		name       string
+		start      time.Time
+		resolution time.Duration
+		indexes    []int
+		expected   string
 	}{
 		{
-			name:     "regular day",
-			day:      time.Date(2024, time.March, 15, 12, 0, 0, 0, loc),
-			count:    24,
-			expected: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23},
+			name:       "deduplicates repeated hour",
+			start:      dstEnd,
+			resolution: time.Hour,
+			indexes:    []int{2, 3},
+			expected:   "van 02:00 tot 02:59",
 		},
 		{
-			name:  "dst start day loses hour",
-			day:   time.Date(2025, time.March, 30, 12, 0, 0, 0, loc),
-			count: 23,
-			expected: []int{
-				0, 1, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12,
-				13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23,
-			},
+			name:       "handles missing hour",
+			start:      dstStart,
+			resolution: time.Hour,
+			indexes:    []int{0, 1, 2},
+			expected:   "van 00:00 tot 01:59 en van 03:00 tot 03:59",
 		},
 		{
-			name:  "dst end day repeats hour",
-			day:   time.Date(2024, time.October, 27, 12, 0, 0, 0, loc),
-			count: 25,
-			expected: []int{
-				0, 1, 2, 2, 3, 4, 5, 6, 7, 8, 9, 10,
-				11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23,
-			},
+			name:       "deduplicates repeated quarter hours",
+			start:      dstEnd,
+			resolution: 15 * time.Minute,
+			indexes:    []int{8, 9, 12, 13},
+			expected:   "van 02:00 tot 02:29",
+		},
+		{
+			name:       "quarter hours",
+			start:      regular,
+			resolution: 15 * time.Minute,
+			indexes:    []int{53, 54, 57},
+			expected:   "van 13:15 tot 13:44 en van 14:15 tot 14:29",
 		},
 	}
 
-	for _, tc := range testCases {
+	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			actual := hourNumbersForDay(tc.day, tc.count)
-			if !slices.Equal(actual, tc.expected) {
-				t.Fatalf("expected %v, got %v", tc.expected, actual)
+			p := prices.New(tc.start, tc.resolution, make([]float64, 96), prices.Wholesale)
+
+			var slots []prices.Slot
+			for i, s := range p.All() {
+				if slices.Contains(tc.indexes, i) {
+					slots = append(slots, s)
+				}
+			}
+
+			actual := formatSlotRanges(slots, tc.resolution)
+			if actual != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, actual)
 			}
 		})
 	}
 }
 
-func TestFormatHourRanges(t *testing.T) {
+func TestSlotsDSTEnd(t *testing.T) {
+	loc := datetime.Location()
+
+	// On 27 October 2024, clocks go back from 03:00 to 02:00, so the day has 25 hours and 100 quarter hours.
+	tomorrow := time.Date(2024, time.October, 27, 0, 0, 0, 0, loc)
+
 	tests := []struct {
-		name     string
-		indexes  []int
-		hours    []int
-		expected string
+		name         string
+		count        int
+		quarterHours bool
+		want         []string // the slots from 01:00 up to 03:00, as "start–end"
 	}{
 		{
-			name:     "deduplicates repeated hour",
-			indexes:  []int{2, 3},
-			hours:    []int{0, 1, 2, 2, 3},
-			expected: "van 02:00 tot 02:59",
+			name:  "hours",
+			count: 25,
+			want:  []string{"01:00–01:59", "02:00–02:59", "02:00–02:59", "03:00–03:59"},
 		},
 		{
-			name:     "handles missing hour",
-			indexes:  []int{0, 1, 2},
-			hours:    []int{0, 1, 3, 4},
-			expected: "van 00:00 tot 01:59 en van 03:00 tot 03:59",
+			name:         "quarter hours",
+			count:        100,
+			quarterHours: true,
+			want: []string{
+				"01:00–01:14", "01:15–01:29", "01:30–01:44", "01:45–01:59",
+				"02:00–02:14", "02:15–02:29", "02:30–02:44", "02:45–02:59",
+				"02:00–02:14", "02:15–02:29", "02:30–02:44", "02:45–02:59",
+				"03:00–03:14",
+			},
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			actual := formatHourRanges(tc.indexes, tc.hours)
-			if actual != tc.expected {
-				t.Fatalf("expected %q, got %q", tc.expected, actual)
+			ps := pricestest.Constant(tc.count, 0.1)
+
+			data, err := getTemplateData(context.Background(), internal.Sources{Primary: fakeSource{prices: ps}}, prices.Wholesale, tomorrow, reportOptions{QuarterHours: tc.quarterHours})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(data.Slots) != tc.count {
+				t.Fatalf("got %d slots, want %d", len(data.Slots), tc.count)
+			}
+
+			perHour := tc.count / 25
+			last := data.Slots[len(data.Slots)-1]
+
+			var got []string
+			for _, s := range data.Slots[perHour : perHour+len(tc.want)] {
+				got = append(got, s.Start+"–"+s.End)
+			}
+
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("got slots %v, want %v", got, tc.want)
+			}
+
+			if last.End != "23:59" {
+				t.Errorf("got last slot ending at %s, want 23:59", last.End)
 			}
 		})
 	}
 }
+
+func TestCheapestHoursDSTEnd(t *testing.T) {
+	loc := datetime.Location()
+
+	// The two 02:00 hours (indexes 2 and 3) are among the six cheapest, but 02:00 is listed only once.
+	ps := pricestest.Constant(25, 0.2)
+	ps[1], ps[2], ps[3], ps[4], ps[5], ps[6] = 0.01, 0.01, 0.01, 0.01, 0.01, 0.01
+
+	tomorrow := time.Date(2024, time.October, 27, 0, 0, 0, 0, loc)
+
+	data, err := getTemplateData(context.Background(), internal.Sources{Primary: fakeSource{prices: ps}}, prices.Wholesale, tomorrow, reportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if data.CheapestHours != "van 01:00 tot 05:59" || data.CheapestCount != 5 {
+		t.Errorf("got %d cheapest hours %q, want 5 van 01:00 tot 05:59", data.CheapestCount, data.CheapestHours)
+	}
+
+	_, long, err := report(*data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(long, "De 5 goedkoopste uren: van 01:00 tot 05:59") {
+		t.Errorf("unexpected report %q", long)
+	}
+}
+
+func TestGetTemplateData(t *testing.T) {
+	loc := datetime.Location()
+
+	ps := pricestest.Constant(24, 0.1)
+	ps[3] = -0.2
+	ps[18] = 0.4
+
+	sources := internal.Sources{Primary: fakeSource{prices: ps}}
+	tariff := prices.Tariff{EnergyTax: 0.1, VAT: 0.21}
+	tomorrow := time.Date(2025, time.March, 15, 0, 0, 0, 0, loc)
+
+	data, err := getTemplateData(context.Background(), sources, tariff, tomorrow, reportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if data.TomorrowDate != "zaterdag 15 maart 2025" {
+		t.Errorf("unexpected date %q", data.TomorrowDate)
+	}
+
+	if data.HighHours != "van 18:00 tot 18:59" {
+		t.Errorf("unexpected high hours %q", data.HighHours)
+	}
+
+	if data.LowHours != "van 03:00 tot 03:59" {
+		t.Errorf("unexpected low hours %q", data.LowHours)
+	}
+
+	if data.CheapestHours != "van 00:00 tot 05:59" {
+		t.Errorf("unexpected cheapest hours %q", data.CheapestHours)
+	}
+
+	// All-in prices are €0,24 except for €-0,12 at 03:00 and €0,61 at 18:00.
+	if data.MedianFormatted != "€\u00a00,24" || data.SpreadFormatted != "€\u00a00,73" {
+		t.Errorf("unexpected median %q or spread %q", data.MedianFormatted, data.SpreadFormatted)
+	}
+
+	if !bytes.HasPrefix(data.Chart, []byte("\x89PNG")) {
+		t.Errorf("expected the chart to be a PNG image")
+	}
+
+	if !strings.Contains(data.ChartAlt, "pieken 's avonds om 18:00") {
+		t.Errorf("unexpected chart alt text %q", data.ChartAlt)
+	}
+
+	if len(data.Slots) != 24 {
+		t.Fatalf("expected 24 slots, got %d", len(data.Slots))
+	}
+
+	if e := data.Slots[3].Emoji; e != "💶" {
+		t.Errorf("unexpected emoji %q for negative price", e)
+	}
+
+	if e := data.Slots[18].Emoji; e != prices.VeryExpensive.Emoji() {
+		t.Errorf("unexpected emoji %q for highest price", e)
+	}
+
+	if e := data.Slots[0].Emoji; e != prices.Normal.Emoji() {
+		t.Errorf("unexpected emoji %q for regular price", e)
+	}
+
+	want := alertData{TomorrowDate: "zaterdag 15 maart 2025", FreePeriods: "van 03:00 tot 03:59", LowestFormatted: "€\u00a0-0,12"}
+	if data.Alert == nil || *data.Alert != want {
+		t.Errorf("got alert %+v, want %+v", data.Alert, want)
+	}
+}
+
+func TestGetTemplateDataAlert(t *testing.T) {
+	ps := pricestest.Constant(24, 0.1)
+
+	sources := internal.Sources{Primary: fakeSource{prices: ps}}
+	tariff := prices.Tariff{EnergyTax: 0.1, VAT: 0.21}
+	tomorrow := time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("no alert", func(t *testing.T) {
+		data, err := getTemplateData(context.Background(), sources, tariff, tomorrow, reportOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if data.Alert != nil {
+			t.Errorf("unexpected alert %+v", data.Alert)
+		}
+	})
+
+	t.Run("negative wholesale prices", func(t *testing.T) {
+		ps := slices.Clone(ps)
+		ps[12], ps[13], ps[15] = -0.01, -0.02, -0.01
+
+		data, err := getTemplateData(context.Background(), internal.Sources{Primary: fakeSource{prices: ps}}, tariff, tomorrow, reportOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := alertData{TomorrowDate: "zaterdag 15 maart 2025", NegativePeriods: "van 12:00 tot 13:59 en van 15:00 tot 15:59", LowestFormatted: "€\u00a00,10"}
+		if data.Alert == nil || *data.Alert != want {
+			t.Fatalf("got alert %+v, want %+v", data.Alert, want)
+		}
+
+		short, long, err := alert(*data.Alert)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.Contains(short, "Negatieve marktprijzen") || !strings.Contains(long, "Terugleveren kost dan geld") {
+			t.Errorf("unexpected alerts %q and %q", short, long)
+		}
+
+		if strings.Contains(short, "Gratis") || strings.Contains(long, "gratis") {
+			t.Errorf("alerts %q and %q call electricity free", short, long)
+		}
+	})
+
+	t.Run("free and negative wholesale prices", func(t *testing.T) {
+		ps := slices.Clone(ps)
+		ps[3], ps[12], ps[13] = -0.2, -0.01, -0.02
+
+		data, err := getTemplateData(context.Background(), internal.Sources{Primary: fakeSource{prices: ps}}, tariff, tomorrow, reportOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := alertData{TomorrowDate: "zaterdag 15 maart 2025", FreePeriods: "van 03:00 tot 03:59", NegativePeriods: "van 12:00 tot 13:59", LowestFormatted: "€\u00a0-0,12"}
+		if data.Alert == nil || *data.Alert != want {
+			t.Fatalf("got alert %+v, want %+v", data.Alert, want)
+		}
+
+		short, long, err := alert(*data.Alert)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		wantShort := "⚠️ Gratis stroom op zaterdag 15 maart 2025\n\nGratis: van 03:00 tot 03:59\nNegatieve marktprijs: van 12:00 tot 13:59\nLaagste prijs: €\u00a0-0,12 per kWh"
+		if short != wantShort {
+			t.Errorf("got short alert %q, want %q", short, wantShort)
+		}
+
+		wantLong := "⚠️ <b>Gratis stroom op zaterdag 15 maart 2025</b>\n\nStroom is morgen gratis of je krijgt zelfs geld toe van 03:00 tot 03:59. " +
+			"De marktprijs van stroom is morgen ook negatief van 12:00 tot 13:59. Terugleveren kost dan geld. De laagste prijs is €\u00a0-0,12 per kWh."
+		if long != wantLong {
+			t.Errorf("got long alert %q, want %q", long, wantLong)
+		}
+	})
+}
+
+func TestGetTemplateDataThresholds(t *testing.T) {
+	ps := make([]float64, 24)
+	for i := range ps {
+		ps[i] = float64(i) / 100
+	}
+
+	sources := internal.Sources{Primary: fakeSource{prices: ps}}
+	thresholds := prices.Thresholds{VeryCheap: 0.05, Cheap: 0.1, Expensive: 0.15, VeryExpensive: 0.2}
+	tomorrow := time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	data, err := getTemplateData(context.Background(), sources, prices.Wholesale, tomorrow, reportOptions{Thresholds: &thresholds})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[int]prices.Tier{1: prices.VeryCheap, 7: prices.Cheap, 12: prices.Normal, 17: prices.Expensive, 23: prices.VeryExpensive}
+	for i, tier := range want {
+		if e := data.Slots[i].Emoji; e != tier.Emoji() {
+			t.Errorf("slot %d: got %q, want %q", i, e, tier.Emoji())
+		}
+	}
+}
+
+func TestGetTemplateDataQuarterHours(t *testing.T) {
+	loc := datetime.Location()
+
+	ps := pricestest.Constant(96, 0.1)
+	ps[53] = -0.2
+
+	sources := internal.Sources{Primary: fakeSource{prices: ps}}
+	tariff := prices.Tariff{EnergyTax: 0.1, VAT: 0.21}
+	tomorrow := time.Date(2025, time.March, 15, 0, 0, 0, 0, loc)
+
+	t.Run("quarter hours", func(t *testing.T) {
+		data, err := getTemplateData(context.Background(), sources, tariff, tomorrow, reportOptions{QuarterHours: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !data.QuarterHours {
+			t.Error("expected quarter hours")
+		}
+
+		if data.LowHours != "van 13:15 tot 13:29" {
+			t.Errorf("unexpected low hours %q", data.LowHours)
+		}
+
+		if data.Alert == nil || data.Alert.FreePeriods != "van 13:15 tot 13:29" {
+			t.Errorf("unexpected alert %+v", data.Alert)
+		}
+
+		if len(data.Slots) != 96 {
+			t.Fatalf("expected 96 slots, got %d", len(data.Slots))
+		}
+
+		if s := data.Slots[53]; s.Start != "13:15" || s.End != "13:29" {
+			t.Errorf("unexpected slot %s – %s", s.Start, s.End)
+		}
+	})
+
+	t.Run("hourly averages", func(t *testing.T) {
+		data, err := getTemplateData(context.Background(), sources, tariff, tomorrow, reportOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if data.QuarterHours {
+			t.Error("expected hourly prices")
+		}
+
+		if data.LowHours != "van 13:00 tot 13:59" {
+			t.Errorf("unexpected low hours %q", data.LowHours)
+		}
+
+		// The average of 13:00 is positive, but the free quarter hour still counts.
+		want := alertData{TomorrowDate: "zaterdag 15 maart 2025", FreePeriods: "van 13:00 tot 13:59", LowestFormatted: "€\u00a0-0,12"}
+		if data.Alert == nil || *data.Alert != want {
+			t.Errorf("got alert %+v, want %+v", data.Alert, want)
+		}
+
+		if len(data.Slots) != 24 {
+			t.Errorf("expected 24 slots, got %d", len(data.Slots))
+		}
+	})
+}
+
+func TestGetTemplateDataErrors(t *testing.T) {
+	tomorrow := time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("source error", func(t *testing.T) {
+		errBoom := errors.New("boom")
+
+		_, err := getTemplateData(context.Background(), internal.Sources{Primary: fakeSource{err: errBoom}}, prices.Wholesale, tomorrow, reportOptions{})
+		if !errors.Is(err, errBoom) {
+			t.Fatalf("expected %v, got %v", errBoom, err)
+		}
+	})
+
+	t.Run("too few prices", func(t *testing.T) {
+		_, err := getTemplateData(context.Background(), internal.Sources{Primary: fakeSource{prices: make([]float64, 12)}}, prices.Wholesale, tomorrow, reportOptions{})
+		if !errors.Is(err, internal.ErrPriceLength) {
+			t.Fatalf("expected %v, got %v", internal.ErrPriceLength, err)
+		}
+	})
+}
+
+func TestFormatChange(t *testing.T) {
+	tests := []struct {
+		price, before float64
+		want          string
+	}{
+		{0.22, 0.25, "12% goedkoper dan vandaag"},
+		{0.3, 0.25, "20% duurder dan vandaag"},
+		{0.25, 0.25, "even duur als vandaag"},
+		{0.251, 0.25, "even duur als vandaag"},
+		{0.05, -0.02, "€\u00a00,07 duurder dan vandaag"},
+		{-0.03, 0, "€\u00a00,03 goedkoper dan vandaag"},
+		{0, 0.001, "even duur als vandaag"},
+	}
+
+	for _, tt := range tests {
+		if got := formatChange(tt.price, tt.before, "vandaag"); got != tt.want {
+			t.Errorf("formatChange(%v, %v): got %q, want %q", tt.price, tt.before, got, tt.want)
+		}
+	}
+}
+
+func TestGetTemplateDataHistory(t *testing.T) {
+	loc := datetime.Location()
+
+	tomorrow := time.Date(2025, time.March, 15, 0, 0, 0, 0, loc)
+	h := history.New(t.TempDir())
+
+	today := tomorrow.AddDate(0, 0, -1)
+	if err := h.Save(prices.New(today, time.Hour, pricestest.Constant(24, 0.2), prices.Wholesale)); err != nil {
+		t.Fatal(err)
+	}
+
+	sources := internal.Sources{Primary: fakeSource{prices: pricestest.Constant(96, 0.15)}}
+
+	data, err := getTemplateData(context.Background(), sources, prices.Wholesale, tomorrow, reportOptions{History: h})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := comparison{Average: "25% goedkoper dan vandaag", High: "25% goedkoper dan vandaag", Low: "25% goedkoper dan vandaag"}
+	if data.ComparedToday == nil || *data.ComparedToday != want {
+		t.Errorf("got %+v, want %+v", data.ComparedToday, want)
+	}
+
+	if data.ComparedLastWeek != nil {
+		t.Errorf("unexpected comparison with last week %+v", data.ComparedLastWeek)
+	}
+
+	saved, err := h.Load(tomorrow)
+	if err != nil {
+		t.Fatalf("expected tomorrow's prices to be saved: %v", err)
+	}
+
+	if saved.Resolution() != 15*time.Minute {
+		t.Errorf("got resolution %v, want quarter-hourly prices to be saved as is", saved.Resolution())
+	}
+}
+
+func TestMonitorURL(t *testing.T) {
+	cfg := config.Cronitor{URL: "https://cronitor.link/p/key/report", SummaryURL: "https://cronitor.link/p/key/summary"}
+
+	if got := monitorURL(cfg, "day"); got != cfg.URL {
+		t.Errorf("got %q for the daily report, want %q", got, cfg.URL)
+	}
+
+	for _, period := range []string{"week", "month", "year"} {
+		if got := monitorURL(cfg, period); got != cfg.SummaryURL {
+			t.Errorf("got %q for period %s, want %q", got, period, cfg.SummaryURL)
+		}
+	}
+
+	// Without a summary monitor, summaries aren't monitored at all.
+	if got := monitorURL(config.Cronitor{URL: cfg.URL}, "week"); got != "" {
+		t.Errorf("got %q without a summary monitor, want none", got)
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/heyajulia/savvy/internal"
+	"github.com/heyajulia/savvy/internal/config"
+	"github.com/heyajulia/savvy/internal/source"
+)
+
+func newSources(cfg config.Source) (internal.Sources, error) {
+	opts := source.Options{EntsoEToken: cfg.EntsoEToken}
+
+	primary, err := source.New(cfg.Name, opts)
+	if err != nil {
+		return internal.Sources{}, fmt.Errorf("primary source: %w", err)
+	}
+
+	sources := internal.Sources{
+		Primary:   primary,
+		Tolerance: cfg.Tolerance,
+		Strict:    cfg.Strict,
+	}
+
+	if cfg.Secondary != "" {
+		secondary, err := source.New(cfg.Secondary, opts)
+		if err != nil {
+			return internal.Sources{}, fmt.Errorf("secondary source: %w", err)
+		}
+
+		sources.Secondary = secondary
+	}
+
+	return sources, nil
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/heyajulia/savvy/internal"
+	"github.com/heyajulia/savvy/internal/config"
+	"github.com/heyajulia/savvy/internal/datetime"
+	"github.com/heyajulia/savvy/internal/history"
+	"github.com/heyajulia/savvy/internal/prices"
+	"github.com/heyajulia/savvy/internal/ranges"
+	"github.com/heyajulia/savvy/internal/stamp"
+)
+
+var errUnknownPeriod = errors.New("unknown period")
+
+// period is a range of whole days that a summary covers.
+type period struct {
+	// Kind is "week", "month" or "year".
+	Kind string
+
+	// Start is the first day of the period, and End is the day after the last one.
+	Start, End time.Time
+}
+
+// lastPeriod returns the last complete period of the given kind before now. Weeks start on Monday.
+func lastPeriod(kind string, now time.Time) (period, error) {
+	today := datetime.StartOfDay(now)
+
+	switch kind {
+	case "week":
+		monday := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+
+		return period{Kind: kind, Start: monday.AddDate(0, 0, -7), End: monday}, nil
+	case "month":
+		first := today.AddDate(0, 0, 1-today.Day())
+
+		return period{Kind: kind, Start: first.AddDate(0, -1, 0), End: first}, nil
+	case "year":
+		first := today.AddDate(0, 0, 1-today.YearDay())
+
+		return period{Kind: kind, Start: first.AddDate(-1, 0, 0), End: first}, nil
+	default:
+		return period{}, fmt.Errorf("%w: %q", errUnknownPeriod, kind)
+	}
+}
+
+// Key identifies the period among periods of the same kind, such as "2025-W11", "2025-03" or "2025".
+func (p period) Key() string {
+	switch p.Kind {
+	case "week":
+		year, week := p.Start.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case "year":
+		return p.Start.Format("2006")
+	default:
+		return p.Start.Format("2006-01")
+	}
+}
+
+// Days returns the number of days in the period.
+func (p period) Days() int {
+	n := 0
+	for day := p.Start; day.Before(p.End); day = day.AddDate(0, 0, 1) {
+		n++
+	}
+
+	return n
+}
+
+type summaryData struct {
+	Short                  bool
+	Title                  string
+	Period                 string
+	Days                   int
+	ExpectedDays           int
+	AverageFormatted       string
+	CheapestDay            string
+	CheapestFormatted      string
+	MostExpensiveDay       string
+	MostExpensiveFormatted string
+	NegativeHours          int
+	CheapestHour           string
+}
+
+// postSummary posts a summary of the last complete period of the given kind. Days of the period that are missing from
+// the history are fetched first.
+func postSummary(ctx context.Context, cfg config.Report, sources internal.Sources, kind string) error {
+	if cfg.HistoryDir == "" {
+		return errors.New("summaries need a history, but HISTORY_DIR is not set")
+	}
+
+	p, err := lastPeriod(kind, datetime.Now())
+	if err != nil {
+		return err
+	}
+
+	s := stamp.NewKeyed(cfg.StampDir, p.Kind, p.Key())
+
+	exists, err := s.Exists()
+	if err != nil {
+		return fmt.Errorf("check stamp: %w", err)
+	}
+
+	if exists {
+		slog.Info("summary already sent", slog.String("period", p.Kind), slog.String("key", p.Key()))
+		return nil
+	}
+
+	h := history.New(cfg.HistoryDir)
+
+	// A summary of the days that could be fetched is better than no summary at all.
+	if err := backfill(ctx, backfillOptions{Sources: sources, Tariff: cfg.Tariff, History: h, Delay: 2 * time.Second}, p.Start, p.End.AddDate(0, 0, -1)); err != nil {
+		slog.Warn("could not fill in missing days", slog.Any("err", err))
+	}
+
+	if p.Kind == "year" {
+		if err := postRecap(cfg, h, p); err != nil {
+			return err
+		}
+	} else {
+		data, err := getSummaryData(h, p)
+		if err != nil {
+			return fmt.Errorf("get summary data: %w", err)
+		}
+
+		short, long, err := summary(*data)
+		if err != nil {
+			return fmt.Errorf("get summaries: %w", err)
+		}
+
+		url, _, err := postToTelegram(long, cfg.Telegram.Token, cfg.Telegram.ChatID, cfg.Telegram.ChannelName)
+		if err != nil {
+			return fmt.Errorf("post summary to telegram: %w", err)
+		}
+
+		if err := postToBluesky(short, cfg.Bluesky.Identifier, cfg.Bluesky.Password, url); err != nil {
+			return fmt.Errorf("post summary to bluesky: %w", err)
+		}
+
+		if err := postToMastodon(short, cfg.Mastodon, url); err != nil {
+			slog.Error("could not post summary to mastodon", slog.Any("err", err))
+		}
+	}
+
+	if err := s.Stamp(); err != nil {
+		return fmt.Errorf("create stamp: %w", err)
+	}
+
+	if err := s.Prune(); err != nil {
+		return fmt.Errorf("prune stamps: %w", err)
+	}
+
+	return nil
+}
+
+func getSummaryData(h *history.Store, p period) (*summaryData, error) {
+	days, err := h.Range(p.Start, p.End.AddDate(0, 0, -1))
+	if err != nil {
+		return nil, fmt.Errorf("load prices: %w", err)
+	}
+
+	s, err := prices.Summarize(days)
+	if err != nil {
+		return nil, fmt.Errorf("summarise prices from %s: %w", p.Start.Format(time.DateOnly), err)
+	}
+
+	data := summaryData{
+		Days:                   s.Days,
+		ExpectedDays:           p.Days(),
+		AverageFormatted:       prices.Format(s.Average),
+		CheapestDay:            datetime.Format(s.Cheapest.Start()),
+		CheapestFormatted:      prices.Format(s.Cheapest.Hourly().Average()),
+		MostExpensiveDay:       datetime.Format(s.MostExpensive.Start()),
+		MostExpensiveFormatted: prices.Format(s.MostExpensive.Hourly().Average()),
+		NegativeHours:          s.NegativeHours,
+		CheapestHour:           ranges.Format([]ranges.Range{ranges.Single(s.CheapestHour)}),
+	}
+
+	switch p.Kind {
+	case "week":
+		year, week := p.Start.ISOWeek()
+
+		data.Title = "Weekoverzicht"
+		data.Period = fmt.Sprintf("week %d van %d", week, year)
+	case "month":
+		data.Title = "Maandoverzicht"
+		data.Period = datetime.FormatMonth(p.Start)
+	}
+
+	return &data, nil
+}
+
+func summary(data summaryData) (short, long string, err error) {
+	var sb strings.Builder
+
+	data.Short = true
+	if err := templates.ExecuteTemplate(&sb, "summary.tmpl", data); err != nil {
+		return "", "", fmt.Errorf("render short summary: %w", err)
+	}
+
+	short = sb.String()
+	sb.Reset()
+
+	data.Short = false
+	if err := templates.ExecuteTemplate(&sb, "summary.tmpl", data); err != nil {
+		return "", "", fmt.Errorf("render long summary: %w", err)
+	}
+
+	long = sb.String()
+
+	return
+}
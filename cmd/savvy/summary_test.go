@@ -0,0 +1,135 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/heyajulia/savvy/internal/datetime"
+	"github.com/heyajulia/savvy/internal/history"
+	"github.com/heyajulia/savvy/internal/prices"
+)
+
+func TestLastPeriod(t *testing.T) {
+	loc := datetime.Location()
+
+	tests := []struct {
+		name      string
+		kind      string
+		now       time.Time
+		wantStart string
+		wantEnd   string
+		wantKey   string
+		wantDays  int
+	}{
+		{"week from monday", "week", time.Date(2025, time.March, 17, 9, 0, 0, 0, loc), "2025-03-10", "2025-03-17", "2025-W11", 7},
+		{"week from sunday", "week", time.Date(2025, time.March, 23, 23, 0, 0, 0, loc), "2025-03-10", "2025-03-17", "2025-W11", 7},
+		{"week across new year", "week", time.Date(2025, time.January, 1, 9, 0, 0, 0, loc), "2024-12-23", "2024-12-30", "2024-W52", 7},
+		{"month", "month", time.Date(2025, time.April, 1, 9, 0, 0, 0, loc), "2025-03-01", "2025-04-01", "2025-03", 31},
+		{"month across new year", "month", time.Date(2025, time.January, 15, 9, 0, 0, 0, loc), "2024-12-01", "2025-01-01", "2024-12", 31},
+		{"year", "year", time.Date(2025, time.January, 1, 9, 0, 0, 0, loc), "2024-01-01", "2025-01-01", "2024", 366},
+		{"february", "month", time.Date(2024, time.March, 31, 23, 30, 0, 0, loc), "2024-02-01", "2024-03-01", "2024-02", 29},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := lastPeriod(tt.kind, tt.now)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := p.Start.Format(time.DateOnly); got != tt.wantStart {
+				t.Errorf("got start %s, want %s", got, tt.wantStart)
+			}
+
+			if got := p.End.Format(time.DateOnly); got != tt.wantEnd {
+				t.Errorf("got end %s, want %s", got, tt.wantEnd)
+			}
+
+			if p.Key() != tt.wantKey {
+				t.Errorf("got key %s, want %s", p.Key(), tt.wantKey)
+			}
+
+			if p.Days() != tt.wantDays {
+				t.Errorf("got %d days, want %d", p.Days(), tt.wantDays)
+			}
+		})
+	}
+
+	if _, err := lastPeriod("decade", time.Now()); !errors.Is(err, errUnknownPeriod) {
+		t.Errorf("got %v, want %v", err, errUnknownPeriod)
+	}
+}
+
+func TestGetSummaryData(t *testing.T) {
+	loc := datetime.Location()
+
+	h := history.New(t.TempDir())
+
+	// Prices for the whole week, except for Sunday.
+	for i, price := range []float64{0.2, 0.1, 0.3, 0.2, 0.2, 0.2} {
+		ps := make([]float64, 24)
+		for h := range ps {
+			ps[h] = price
+		}
+		ps[4] = -0.01
+
+		start := time.Date(2025, time.March, 10+i, 0, 0, 0, 0, loc)
+		if err := h.Save(prices.New(start, time.Hour, ps, prices.Wholesale)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p, err := lastPeriod("week", time.Date(2025, time.March, 17, 9, 0, 0, 0, loc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := getSummaryData(h, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := summaryData{
+		Title:                  "Weekoverzicht",
+		Period:                 "week 11 van 2025",
+		Days:                   6,
+		ExpectedDays:           7,
+		AverageFormatted:       "€\u00a00,19",
+		CheapestDay:            "dinsdag 11 maart 2025",
+		CheapestFormatted:      "€\u00a00,10",
+		MostExpensiveDay:       "woensdag 12 maart 2025",
+		MostExpensiveFormatted: "€\u00a00,29",
+		NegativeHours:          6,
+		CheapestHour:           "van 04:00 tot 04:59",
+	}
+
+	if *data != want {
+		t.Errorf("got %+v, want %+v", *data, want)
+	}
+
+	short, long, err := summary(*data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(short, "Weekoverzicht week 11 van 2025") {
+		t.Errorf("unexpected short summary %q", short)
+	}
+
+	if !strings.Contains(long, "6 uur gratis") || !strings.Contains(long, "6 van de 7 dagen") {
+		t.Errorf("unexpected long summary %q", long)
+	}
+}
+
+func TestGetSummaryDataEmpty(t *testing.T) {
+	p, err := lastPeriod("month", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := getSummaryData(history.New(t.TempDir()), p); !errors.Is(err, prices.ErrNoDays) {
+		t.Errorf("got %v, want %v", err, prices.ErrNoDays)
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"time"
+
+	"github.com/heyajulia/savvy/internal/config"
+	"github.com/heyajulia/savvy/internal/prices"
+)
+
+// taxRatesMargin is how long before the built-in tax rates run out the report starts warning about it, so that there is
+// time to add the new rates or set TF_ENERGY_TAX.
+const taxRatesMargin = 6 * 7 * 24 * time.Hour
+
+// taxRatesRunOut reports whether the tariff needs tax rates for day, plus a margin, that neither the built-in table nor
+// the TF_ENERGY_TAX overrides provide.
+func taxRatesRunOut(cfg config.Tariff, day time.Time) bool {
+	if cfg.Wholesale {
+		return false
+	}
+
+	until := prices.TaxRatesUntil()
+
+	for year := until.Year(); year <= day.Add(taxRatesMargin).Year(); year++ {
+		if _, ok := cfg.EnergyTax[year]; !ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func tariffOn(cfg config.Tariff, day time.Time) (prices.Tariff, error) {
+	if cfg.Wholesale {
+		return prices.Wholesale, nil
+	}
+
+	schedule := prices.TariffSchedule{
+		EnergyTax:   cfg.EnergyTax,
+		PurchaseFee: cfg.PurchaseFee,
+		DeliveryFee: cfg.DeliveryFee,
+		VAT:         cfg.VAT,
+	}
+
+	return schedule.On(day)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/heyajulia/savvy/internal/config"
+	"github.com/heyajulia/savvy/internal/datetime"
+	"github.com/heyajulia/savvy/internal/prices"
+)
+
+func TestTariffOnNewYear(t *testing.T) {
+	loc := datetime.Location()
+
+	// The report that runs on 31 December 2026 is about 1 January 2027, the first day after the built-in table.
+	lastDay := time.Date(2026, time.December, 31, 0, 0, 0, 0, loc)
+	newYear := datetime.Tomorrow(lastDay)
+
+	cfg := config.Tariff{PurchaseFee: 0.02, VAT: true}
+
+	if _, err := tariffOn(cfg, lastDay); err != nil {
+		t.Fatalf("got error for %s: %v", lastDay.Format(time.DateOnly), err)
+	}
+
+	if _, err := tariffOn(cfg, newYear); !errors.Is(err, prices.ErrTaxRatesUnknown) {
+		t.Fatalf("got error %v for %s, want %v", err, newYear.Format(time.DateOnly), prices.ErrTaxRatesUnknown)
+	}
+
+	cfg.EnergyTax = map[int]float64{2027: 0.095}
+
+	tariff, err := tariffOn(cfg, newYear)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (prices.Tariff{EnergyTax: 0.095, PurchaseFee: 0.02, VAT: 0.21}); tariff != want {
+		t.Errorf("got %+v, want %+v", tariff, want)
+	}
+}
+
+func TestTaxRatesRunOut(t *testing.T) {
+	loc := datetime.Location()
+
+	tests := []struct {
+		name string
+		cfg  config.Tariff
+		day  time.Time
+		want bool
+	}{
+		{"well before", config.Tariff{}, time.Date(2026, time.October, 1, 0, 0, 0, 0, loc), false},
+		{"weeks before", config.Tariff{}, time.Date(2026, time.December, 1, 0, 0, 0, 0, loc), true},
+		{"after", config.Tariff{}, time.Date(2027, time.January, 1, 0, 0, 0, 0, loc), true},
+		{"overridden", config.Tariff{EnergyTax: map[int]float64{2027: 0.095}}, time.Date(2026, time.December, 1, 0, 0, 0, 0, loc), false},
+		{"override runs out", config.Tariff{EnergyTax: map[int]float64{2027: 0.095}}, time.Date(2027, time.December, 1, 0, 0, 0, 0, loc), true},
+		{"wholesale", config.Tariff{Wholesale: true}, time.Date(2027, time.January, 1, 0, 0, 0, 0, loc), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := taxRatesRunOut(tt.cfg, tt.day); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
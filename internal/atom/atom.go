@@ -0,0 +1,134 @@
+// Package atom keeps an Atom (RFC 4287) feed in a file, for people who follow the reports with a feed reader.
+package atom
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ContentType is the media type of a feed, for serving it over HTTP.
+const ContentType = "application/atom+xml"
+
+// Feed is an Atom feed. Its entries are ordered from newest to oldest.
+type Feed struct {
+	XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string   `xml:"id"`
+	Title   string   `xml:"title"`
+	Updated string   `xml:"updated"`
+	Author  Person   `xml:"author"`
+	Links   []Link   `xml:"link"`
+	Entries []Entry  `xml:"entry"`
+}
+
+type Person struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+type Link struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type Entry struct {
+	// ID permanently identifies the entry. Adding an entry with the ID of an existing one replaces it.
+	ID      string  `xml:"id"`
+	Title   string  `xml:"title"`
+	Updated string  `xml:"updated"`
+	Links   []Link  `xml:"link"`
+	Content Content `xml:"content"`
+}
+
+// Content is XHTML content. Body is the inner XML of the div that wraps it, such as "Hallo<br/>wereld".
+type Content struct {
+	Type string `xml:"type,attr"`
+	Div  div    `xml:"div"`
+}
+
+type div struct {
+	XMLName xml.Name `xml:"http://www.w3.org/1999/xhtml div"`
+	Body    string   `xml:",innerxml"`
+}
+
+// XHTML returns content with the given XHTML body, which must be well-formed.
+func XHTML(body string) Content {
+	return Content{Type: "xhtml", Div: div{Body: body}}
+}
+
+// Body returns the content's XHTML body.
+func (c Content) Body() string {
+	return c.Div.Body
+}
+
+// FormatTime formats t as an Atom date.
+func FormatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// Append adds e to the feed in the file at path, and keeps only the newest keep entries. If the file doesn't exist yet,
+// it is created from feed; otherwise, its entries are kept, but the rest of feed replaces what is in the file.
+func Append(path string, feed Feed, e Entry, keep int) error {
+	existing, err := Read(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	feed.Entries = []Entry{e}
+
+	for _, old := range existing.Entries {
+		if old.ID != e.ID && len(feed.Entries) < keep {
+			feed.Entries = append(feed.Entries, old)
+		}
+	}
+
+	feed.Updated = e.Updated
+
+	return Write(path, feed)
+}
+
+// Read reads the feed in the file at path.
+func Read(path string) (Feed, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Feed{}, fmt.Errorf("atom: read file %q: %w", path, err)
+	}
+
+	var feed Feed
+	if err := xml.Unmarshal(b, &feed); err != nil {
+		return Feed{}, fmt.Errorf("atom: unmarshal file %q: %w", path, err)
+	}
+
+	return feed, nil
+}
+
+// Write writes the feed to the file at path.
+func Write(path string, feed Feed) error {
+	b, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return fmt.Errorf("atom: marshal feed: %w", err)
+	}
+
+	b = append([]byte(xml.Header), b...)
+	b = append(b, '\n')
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("atom: create directory %q: %w", filepath.Dir(path), err)
+	}
+
+	// Write to a temporary file first, so that feed readers never see a partially written feed.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0644); err != nil {
+		return fmt.Errorf("atom: write file %q: %w", tmp, err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("atom: rename file %q: %w", tmp, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package atom
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "feed", "energieprijzen.xml")
+
+	feed := Feed{
+		ID:     "https://t.me/energieprijzen",
+		Title:  "Energieprijzen",
+		Author: Person{Name: "Savvy"},
+		Links:  []Link{{Rel: "alternate", Href: "https://t.me/energieprijzen"}},
+	}
+
+	entry := func(day int) Entry {
+		url := fmt.Sprintf("https://t.me/energieprijzen/%d", day)
+
+		return Entry{
+			ID:      url,
+			Title:   fmt.Sprintf("%d maart 2025", day),
+			Updated: FormatTime(time.Date(2025, time.March, day, 14, 0, 0, 0, time.UTC)),
+			Links:   []Link{{Rel: "alternate", Href: url}},
+			Content: XHTML(fmt.Sprintf("Dag %d<br/><strong>vet</strong>", day)),
+		}
+	}
+
+	for day := 1; day <= 4; day++ {
+		if err := Append(path, feed, entry(day), 3); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Appending the same entry again replaces it.
+	if err := Append(path, feed, entry(4), 3); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.ID != feed.ID || got.Updated != "2025-03-04T14:00:00Z" {
+		t.Errorf("got feed ID %q, updated %q", got.ID, got.Updated)
+	}
+
+	var titles []string
+	for _, e := range got.Entries {
+		titles = append(titles, e.Title)
+	}
+
+	if want := "4 maart 2025,3 maart 2025,2 maart 2025"; strings.Join(titles, ",") != want {
+		t.Errorf("got entries %q, want %q", strings.Join(titles, ","), want)
+	}
+
+	if body := got.Entries[0].Content.Body(); body != "Dag 4<br/><strong>vet</strong>" {
+		t.Errorf("got body %q", body)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{`<?xml version="1.0" encoding="UTF-8"?>`, `<feed xmlns="http://www.w3.org/2005/Atom">`, `<content type="xhtml">`, `<div xmlns="http://www.w3.org/1999/xhtml">Dag 4<br/>`} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected the feed to contain %q, got:\n%s", want, b)
+		}
+	}
+}
+
+func TestReadNotExist(t *testing.T) {
+	if _, err := Read(filepath.Join(t.TempDir(), "feed.xml")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("got %v, want %v", err, fs.ErrNotExist)
+	}
+}
@@ -0,0 +1,115 @@
+package atom
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// telegramElements maps the elements that Telegram's HTML parse mode supports to their XHTML equivalents.
+var telegramElements = map[string]string{
+	"b":          "strong",
+	"strong":     "strong",
+	"i":          "em",
+	"em":         "em",
+	"u":          "u",
+	"ins":        "u",
+	"s":          "del",
+	"strike":     "del",
+	"del":        "del",
+	"code":       "code",
+	"pre":        "pre",
+	"blockquote": "blockquote",
+	"a":          "a",
+}
+
+// FromTelegramHTML converts a message in Telegram's HTML format to an XHTML body. Telegram keeps line breaks, so they
+// become <br/> elements, except in preformatted text. Elements that Telegram doesn't support, such as spoilers, are left
+// out, but their text is kept.
+func FromTelegramHTML(s string) (string, error) {
+	d := xml.NewDecoder(strings.NewReader("<message>" + s + "</message>"))
+	d.Strict = false
+	d.Entity = xml.HTMLEntity
+
+	var (
+		b     strings.Builder
+		open  []string // the XHTML elements that are open, or "" for elements that are left out
+		inPre int
+	)
+
+	for {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return "", fmt.Errorf("atom: parse telegram html: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			name := telegramElements[t.Name.Local]
+			open = append(open, name)
+
+			if name == "" {
+				continue
+			}
+
+			if name == "pre" {
+				inPre++
+			}
+
+			b.WriteString("<" + name)
+
+			if name == "a" {
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "href" {
+						b.WriteString(` href="`)
+						_ = xml.EscapeText(&b, []byte(attr.Value))
+						b.WriteString(`"`)
+					}
+				}
+			}
+
+			b.WriteString(">")
+		case xml.EndElement:
+			if len(open) == 0 {
+				continue
+			}
+
+			name := open[len(open)-1]
+			open = open[:len(open)-1]
+
+			if name == "" {
+				continue
+			}
+
+			if name == "pre" {
+				inPre--
+			}
+
+			b.WriteString("</" + name + ">")
+		case xml.CharData:
+			writeText(&b, string(t), inPre > 0)
+		}
+	}
+
+	return b.String(), nil
+}
+
+func writeText(b *strings.Builder, text string, pre bool) {
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			if pre {
+				b.WriteString("\n")
+			} else {
+				b.WriteString("<br/>")
+			}
+		}
+
+		_ = xml.EscapeText(b, []byte(line))
+	}
+}
@@ -0,0 +1,29 @@
+package atom
+
+import "testing"
+
+func TestFromTelegramHTML(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Hallo\nwereld", "Hallo<br/>wereld"},
+		{"<b>vet</b> en <i>schuin</i>", "<strong>vet</strong> en <em>schuin</em>"},
+		{"1 &lt; 2 &amp; Jan&#39;s", "1 &lt; 2 &amp; Jan&#39;s"},
+		{`<a href="https://t.me/x?a=1&amp;b=2">link</a>`, `<a href="https://t.me/x?a=1&amp;b=2">link</a>`},
+		{"<blockquote><code>\n💚 00:00 – 00:59\n</code></blockquote>", "<blockquote><code><br/>💚 00:00 – 00:59<br/></code></blockquote>"},
+		{"<pre>a\nb</pre>", "<pre>a\nb</pre>"},
+		{"<tg-spoiler>geheim</tg-spoiler>", "geheim"},
+	}
+
+	for _, tt := range tests {
+		got, err := FromTelegramHTML(tt.in)
+		if err != nil {
+			t.Errorf("FromTelegramHTML(%q): %v", tt.in, err)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf("FromTelegramHTML(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package bsky
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"time"
@@ -11,6 +12,7 @@ import (
 	lexutil "github.com/bluesky-social/indigo/lex/util"
 	"github.com/bluesky-social/indigo/util"
 	"github.com/bluesky-social/indigo/xrpc"
+	"github.com/heyajulia/savvy/internal"
 	"github.com/heyajulia/savvy/pkg/discoverpds"
 )
 
@@ -48,45 +50,114 @@ func Login(username, password string) (*client, error) {
 	return &client{client: xrpcc}, nil
 }
 
-func (c *client) Post(summary, telegramUrl string) error {
-	const anchorText = "👉 Bekijk het volledige energiebericht op Telegram"
+// Image is an image to attach to a post.
+type Image struct {
+	// Data is the encoded image, such as a PNG.
+	Data []byte
 
-	text := fmt.Sprintf("%s\n\n%s", summary, anchorText)
+	// Alt describes the image for people who can't see it.
+	Alt string
+}
+
+func (c *client) Post(summary, telegramUrl string, images ...Image) error {
+	embed, err := c.embedImages(images)
+	if err != nil {
+		return err
+	}
+
+	post := linkedPost(summary, "👉 Bekijk het volledige energiebericht op Telegram", telegramUrl)
+	post.Embed = embed
+
+	if _, err := c.createGated(post); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// PostThread posts a thread of posts. The first post links to the Telegram message like Post does, and every other
+// post replies to the one before it.
+func (c *client) PostThread(posts []string, telegramUrl string) error {
+	if len(posts) == 0 {
+		return fmt.Errorf("bsky: empty thread")
+	}
+
+	created, err := c.createGated(linkedPost(posts[0], "👉 Bekijk het volledige bericht op Telegram", telegramUrl))
+	if err != nil {
+		return err
+	}
+
+	if created == nil {
+		return fmt.Errorf("bsky: no reference to root post")
+	}
+
+	root := &comatproto.RepoStrongRef{Uri: created.Uri, Cid: created.Cid}
+	parent := root
+
+	for i, post := range posts[1:] {
+		reply, err := c.createRecord("app.bsky.feed.post", nil, &appbsky.FeedPost{
+			CreatedAt: time.Now().UTC().Format(time.RFC3339),
+			Langs:     []string{"nl"},
+			Reply: &appbsky.FeedPost_ReplyRef{
+				Root:   root,
+				Parent: parent,
+			},
+			Text: post,
+		})
+		if err != nil {
+			return fmt.Errorf("bsky: create reply %d: %w", i+1, err)
+		}
+
+		parent = reply
+	}
+
+	return nil
+}
+
+// linkedPost returns a post of text followed by anchorText, which links to url.
+func linkedPost(text, anchorText, url string) *appbsky.FeedPost {
+	text = fmt.Sprintf("%s\n\n%s", text, anchorText)
 
+	return &appbsky.FeedPost{
+		Facets: []*appbsky.RichtextFacet{
+			{
+				Index: &appbsky.RichtextFacet_ByteSlice{
+					ByteStart: int64(len(text) - len(anchorText)),
+					ByteEnd:   int64(len(text)),
+				},
+				Features: []*appbsky.RichtextFacet_Features_Elem{
+					{
+						RichtextFacet_Link: &appbsky.RichtextFacet_Link{
+							Uri: url,
+						},
+					},
+				},
+			},
+		},
+		Langs: []string{"nl"},
+		Tags:  internal.Tags,
+		Text:  text,
+	}
+}
+
+// createGated creates post along with a threadgate that only lets people the user follows reply to it. Both are
+// written at once, so the post is never live without the threadgate. It returns the result of creating the post, which
+// is nil if the server didn't report it.
+func (c *client) createGated(post *appbsky.FeedPost) (*comatproto.RepoApplyWrites_CreateResult, error) {
 	t := time.Now().UTC()
 	ts := t.Format(time.RFC3339)
 	rkey := string(syntax.NewTID(t.UnixMicro(), 42))
 
-	if _, err := comatproto.RepoApplyWrites(context.Background(), c.client, &comatproto.RepoApplyWrites_Input{
+	post.CreatedAt = ts
+
+	out, err := comatproto.RepoApplyWrites(context.Background(), c.client, &comatproto.RepoApplyWrites_Input{
 		Repo: c.client.Auth.Did,
 		Writes: []*comatproto.RepoApplyWrites_Input_Writes_Elem{
 			{
 				RepoApplyWrites_Create: &comatproto.RepoApplyWrites_Create{
 					Collection: "app.bsky.feed.post",
 					Rkey:       &rkey,
-					Value: &lexutil.LexiconTypeDecoder{
-						Val: &appbsky.FeedPost{
-							CreatedAt: ts,
-							Facets: []*appbsky.RichtextFacet{
-								{
-									Index: &appbsky.RichtextFacet_ByteSlice{
-										ByteStart: int64(len(text) - len(anchorText)),
-										ByteEnd:   int64(len(text)),
-									},
-									Features: []*appbsky.RichtextFacet_Features_Elem{
-										{
-											RichtextFacet_Link: &appbsky.RichtextFacet_Link{
-												Uri: telegramUrl,
-											},
-										},
-									},
-								},
-							},
-							Langs: []string{"nl"},
-							Tags:  []string{"energie", "energiebericht", "groen", "duurzaam", "stroom", "klimaat", "klimaatverandering"},
-							Text:  text,
-						},
-					},
+					Value:      &lexutil.LexiconTypeDecoder{Val: post},
 				},
 			},
 			{
@@ -107,9 +178,53 @@ func (c *client) Post(summary, telegramUrl string) error {
 				},
 			},
 		},
-	}); err != nil {
-		return fmt.Errorf("bsky: apply writes: %w", err)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("bsky: apply writes: %w", err)
 	}
 
-	return nil
+	if out == nil || len(out.Results) == 0 {
+		return nil, nil
+	}
+
+	return out.Results[0].RepoApplyWrites_CreateResult, nil
+}
+
+// embedImages uploads the images and returns an embed that shows them. It returns nil if there are no images.
+func (c *client) embedImages(images []Image) (*appbsky.FeedPost_Embed, error) {
+	if len(images) == 0 {
+		return nil, nil
+	}
+
+	embed := &appbsky.EmbedImages{}
+
+	for i, image := range images {
+		out, err := comatproto.RepoUploadBlob(context.Background(), c.client, bytes.NewReader(image.Data))
+		if err != nil {
+			return nil, fmt.Errorf("bsky: upload image %d: %w", i+1, err)
+		}
+
+		embed.Images = append(embed.Images, &appbsky.EmbedImages_Image{
+			Alt:   image.Alt,
+			Image: out.Blob,
+		})
+	}
+
+	return &appbsky.FeedPost_Embed{EmbedImages: embed}, nil
+}
+
+// createRecord creates a record in the user's repository and returns a reference to it. If rkey is nil, the server
+// picks the record key.
+func (c *client) createRecord(collection string, rkey *string, record lexutil.CBOR) (*comatproto.RepoStrongRef, error) {
+	out, err := comatproto.RepoCreateRecord(context.Background(), c.client, &comatproto.RepoCreateRecord_Input{
+		Collection: collection,
+		Repo:       c.client.Auth.Did,
+		Rkey:       rkey,
+		Record:     &lexutil.LexiconTypeDecoder{Val: record},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &comatproto.RepoStrongRef{Uri: out.Uri, Cid: out.Cid}, nil
 }
@@ -0,0 +1,152 @@
+package chart
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/heyajulia/savvy/internal/datetime"
+	"github.com/heyajulia/savvy/internal/prices"
+	"github.com/heyajulia/savvy/internal/ranges"
+)
+
+// AltText describes the chart of p in Dutch, for people who can't see it. It describes the shape of the day, such as
+// "Prijzen dalen 's ochtends naar een dal om 13:00 en pieken 's avonds om 19:00", followed by the key prices.
+func AltText(p *prices.Prices) string {
+	p = p.Hourly()
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Staafdiagram van de stroomprijzen per uur op %s. ", datetime.Format(p.Start()))
+
+	if p.Spread() < 0.01 {
+		fmt.Fprintf(&sb, "De prijs is de hele dag %s per kWh.", prices.Format(p.Average()))
+		return sb.String()
+	}
+
+	sb.WriteString(shape(p))
+
+	fmt.Fprintf(&sb, " Gemiddeld kost stroom %s per kWh, met een laagste prijs van %s en een hoogste prijs van %s.",
+		prices.Format(p.Average()), prices.Format(p.Low()), prices.Format(p.High()))
+
+	if negative := hoursWhere(p.Slots(), func(s prices.Slot) bool { return s.Price < 0 }); len(negative) > 0 {
+		fmt.Fprintf(&sb, " De prijs is negatief %s.", ranges.CollapseAndFormat(negative))
+	}
+
+	return sb.String()
+}
+
+// extreme is the lowest or highest point of the day.
+type extreme struct {
+	low   bool
+	hours []int
+}
+
+// shape describes how prices move through the day, by the order in which the lowest and highest prices occur.
+func shape(p *prices.Prices) string {
+	low := extreme{low: true, hours: hoursOf(p.LowHours())}
+	high := extreme{hours: hoursOf(p.HighHours())}
+
+	first, second := low, high
+	if high.hours[0] < low.hours[0] {
+		first, second = high, low
+	}
+
+	var sb strings.Builder
+
+	if first.hours[0] == startHour(p) {
+		fmt.Fprintf(&sb, "Prijzen beginnen met een %s %s", first.noun(), when(first.hours))
+	} else {
+		fmt.Fprintf(&sb, "Prijzen %s naar een %s %s", first.verb(), first.noun(), when(first.hours))
+	}
+
+	if second.low {
+		fmt.Fprintf(&sb, " en zakken naar een dal %s", when(second.hours))
+	} else {
+		fmt.Fprintf(&sb, " en pieken %s", when(second.hours))
+	}
+
+	if second.hours[len(second.hours)-1] != endHour(p) {
+		if second.low {
+			sb.WriteString(", waarna ze weer stijgen")
+		} else {
+			sb.WriteString(", waarna ze weer dalen")
+		}
+	}
+
+	sb.WriteByte('.')
+
+	return sb.String()
+}
+
+func (e extreme) noun() string {
+	if e.low {
+		return "dal"
+	}
+
+	return "piek"
+}
+
+func (e extreme) verb() string {
+	if e.low {
+		return "dalen"
+	}
+
+	return "stijgen"
+}
+
+// when describes when the given hours are, such as "'s avonds om 19:00" or "van 12:00 tot 13:59".
+func when(hours []int) string {
+	rs := ranges.Collapse(hours)
+
+	if len(rs) > 1 {
+		return ranges.Format(rs)
+	}
+
+	if len(hours) == 1 {
+		return fmt.Sprintf("%s om %02d:00", dayPart(hours[0]), hours[0])
+	}
+
+	return fmt.Sprintf("%s %s", dayPart(hours[0]), ranges.Format(rs))
+}
+
+func dayPart(hour int) string {
+	switch {
+	case hour < 6:
+		return "'s nachts"
+	case hour < 12:
+		return "'s ochtends"
+	case hour < 18:
+		return "'s middags"
+	default:
+		return "'s avonds"
+	}
+}
+
+func hoursOf(slots []prices.Slot) []int {
+	return hoursWhere(slots, func(prices.Slot) bool { return true })
+}
+
+// hoursWhere returns the hours of the slots for which f returns true, in order and without duplicates. An hour repeats
+// on the day DST ends, and with prices per quarter hour.
+func hoursWhere(slots []prices.Slot, f func(prices.Slot) bool) []int {
+	var hours []int
+
+	for _, s := range slots {
+		if f(s) && !slices.Contains(hours, s.Start.Hour()) {
+			hours = append(hours, s.Start.Hour())
+		}
+	}
+
+	slices.Sort(hours)
+
+	return hours
+}
+
+func startHour(p *prices.Prices) int {
+	return p.Start().Hour()
+}
+
+func endHour(p *prices.Prices) int {
+	return p.End().Add(-p.Resolution()).Hour()
+}
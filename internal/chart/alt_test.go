@@ -0,0 +1,134 @@
+package chart
+
+import (
+	"testing"
+	"time"
+
+	"github.com/heyajulia/savvy/internal/datetime"
+	"github.com/heyajulia/savvy/internal/prices"
+	"github.com/heyajulia/savvy/internal/prices/pricestest"
+)
+
+func TestAltText(t *testing.T) {
+	loc := datetime.Location()
+
+	day := time.Date(2025, time.April, 12, 0, 0, 0, 0, loc)
+
+	flat := pricestest.Constant(24, 0.1)
+
+	rising := make([]float64, 24)
+	for i := range rising {
+		rising[i] = float64(i) / 100
+	}
+
+	evening := pricestest.Constant(24, 0.2)
+	evening[0], evening[1] = 0.1, 0.1
+	evening[20] = 0.4
+
+	tests := []struct {
+		name      string
+		wholesale []float64
+		want      string
+	}{
+		{
+			name:      "spring day",
+			wholesale: sampleDay,
+			want: "Staafdiagram van de stroomprijzen per uur op zaterdag 12 april 2025. Prijzen dalen naar een dal 's middags van 12:00 tot 13:59 en pieken 's avonds om 19:00, waarna ze weer dalen." +
+				" Gemiddeld kost stroom €\u00a00,08 per kWh, met een laagste prijs van €\u00a0-0,02 en een hoogste prijs van €\u00a00,18. De prijs is negatief van 11:00 tot 13:59.",
+		},
+		{
+			name:      "flat day",
+			wholesale: flat,
+			want:      "Staafdiagram van de stroomprijzen per uur op zaterdag 12 april 2025. De prijs is de hele dag €\u00a00,10 per kWh.",
+		},
+		{
+			name:      "rising all day",
+			wholesale: rising,
+			want: "Staafdiagram van de stroomprijzen per uur op zaterdag 12 april 2025. Prijzen beginnen met een dal 's nachts om 00:00 en pieken 's avonds om 23:00." +
+				" Gemiddeld kost stroom €\u00a00,12 per kWh, met een laagste prijs van €\u00a00,00 en een hoogste prijs van €\u00a00,23.",
+		},
+		{
+			name:      "cheap night, evening peak",
+			wholesale: evening,
+			want: "Staafdiagram van de stroomprijzen per uur op zaterdag 12 april 2025. Prijzen beginnen met een dal 's nachts van 00:00 tot 01:59 en pieken 's avonds om 20:00, waarna ze weer dalen." +
+				" Gemiddeld kost stroom €\u00a00,20 per kWh, met een laagste prijs van €\u00a00,10 en een hoogste prijs van €\u00a00,40.",
+		},
+		{
+			name:      "negative midday",
+			wholesale: []float64{0.2, 0.2, 0.2, 0.2, 0.2, 0.2, 0.2, 0.2, 0.2, 0.2, 0.1, -0.1, -0.2, -0.1, 0.1, 0.2, 0.2, 0.2, 0.3, 0.2, 0.2, 0.2, 0.2, 0.2},
+			want: "Staafdiagram van de stroomprijzen per uur op zaterdag 12 april 2025. Prijzen dalen naar een dal 's middags om 12:00 en pieken 's avonds om 18:00, waarna ze weer dalen." +
+				" Gemiddeld kost stroom €\u00a00,15 per kWh, met een laagste prijs van €\u00a0-0,20 en een hoogste prijs van €\u00a00,30. De prijs is negatief van 11:00 tot 13:59.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := prices.New(day, time.Hour, tt.wholesale, prices.Wholesale)
+
+			if got := AltText(p); got != tt.want {
+				t.Errorf("got  %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAltTextRepeatedHours(t *testing.T) {
+	loc := datetime.Location()
+
+	// On 27 October 2024, clocks go back from 03:00 to 02:00, so 02:00 comes twice.
+	dstEnd := pricestest.Constant(25, 0.2)
+	dstEnd[2], dstEnd[3] = -0.05, -0.05
+	dstEnd[19] = 0.4
+
+	quarters := pricestest.Constant(96, 0.2)
+	quarters[48], quarters[49], quarters[50], quarters[51], quarters[52] = -0.1, -0.1, -0.1, -0.1, -0.1
+	quarters[76], quarters[77], quarters[78], quarters[79] = 0.4, 0.4, 0.4, 0.4
+
+	tests := []struct {
+		name       string
+		day        time.Time
+		resolution time.Duration
+		wholesale  []float64
+		want       string
+	}{
+		{
+			name:       "DST end",
+			day:        time.Date(2024, time.October, 27, 0, 0, 0, 0, loc),
+			resolution: time.Hour,
+			wholesale:  dstEnd,
+			want: "Staafdiagram van de stroomprijzen per uur op zondag 27 oktober 2024. Prijzen dalen naar een dal 's nachts om 02:00 en pieken 's avonds om 18:00, waarna ze weer dalen." +
+				" Gemiddeld kost stroom €\u00a00,19 per kWh, met een laagste prijs van €\u00a0-0,05 en een hoogste prijs van €\u00a00,40. De prijs is negatief van 02:00 tot 02:59.",
+		},
+		{
+			name:       "quarter hours",
+			day:        time.Date(2025, time.April, 12, 0, 0, 0, 0, loc),
+			resolution: 15 * time.Minute,
+			wholesale:  quarters,
+			want: "Staafdiagram van de stroomprijzen per uur op zaterdag 12 april 2025. Prijzen dalen naar een dal 's middags om 12:00 en pieken 's avonds om 19:00, waarna ze weer dalen." +
+				" Gemiddeld kost stroom €\u00a00,19 per kWh, met een laagste prijs van €\u00a0-0,10 en een hoogste prijs van €\u00a00,40. De prijs is negatief van 12:00 tot 12:59.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := prices.New(tt.day, tt.resolution, tt.wholesale, prices.Wholesale)
+
+			if got := AltText(p); got != tt.want {
+				t.Errorf("got  %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAltTextPeakBeforeDip(t *testing.T) {
+	wholesale := pricestest.Constant(24, 0.2)
+	wholesale[8] = 0.35
+	wholesale[15] = 0.05
+
+	p := prices.New(time.Date(2025, time.April, 12, 0, 0, 0, 0, time.UTC), time.Hour, wholesale, prices.Wholesale)
+
+	want := "Prijzen stijgen naar een piek 's ochtends om 08:00 en zakken naar een dal 's middags om 15:00, waarna ze weer stijgen."
+	if got := shape(p); got != want {
+		t.Errorf("got  %q\nwant %q", got, want)
+	}
+}
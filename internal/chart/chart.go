@@ -0,0 +1,204 @@
+// Package chart draws bar charts of energy prices.
+package chart
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/heyajulia/savvy/internal/prices"
+)
+
+const (
+	// Width and Height are the size of the chart in pixels. Its aspect ratio of 16:9 suits both Telegram and Bluesky.
+	Width  = 1200
+	Height = 675
+
+	marginLeft   = 110
+	marginRight  = 30
+	marginTop    = 30
+	marginBottom = 60
+
+	textScale = 4
+)
+
+var (
+	background = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	grid       = color.RGBA{0xe0, 0xe0, 0xe0, 0xff}
+	axis       = color.RGBA{0x40, 0x40, 0x40, 0xff}
+	text       = color.RGBA{0x20, 0x20, 0x20, 0xff}
+	bar        = color.RGBA{0x45, 0x75, 0xb4, 0xff}
+	high       = mustParseColor(prices.VeryExpensive.Color())
+	low        = mustParseColor(prices.VeryCheap.Color())
+)
+
+// layout is where everything goes on the chart. Both the raster and the vector chart are drawn from it, so that they
+// look the same.
+type layout struct {
+	plot image.Rectangle
+
+	// zero and average are the y coordinates of the zero line and of the average price.
+	zero, average int
+
+	gridLines []gridLine
+	bars      []barShape
+}
+
+type gridLine struct {
+	y     int
+	label string
+}
+
+type barShape struct {
+	rect  image.Rectangle
+	color color.RGBA
+
+	// label is the hour below the bar, if any.
+	label string
+}
+
+func newLayout(p *prices.Prices) layout {
+	plot := image.Rect(marginLeft, marginTop, Width-marginRight, Height-marginBottom)
+
+	lo, hi, step := scale(p.Low(), p.High())
+
+	y := func(price float64) int {
+		return plot.Min.Y + int(math.Round((hi-price)/(hi-lo)*float64(plot.Dy())))
+	}
+
+	l := layout{plot: plot, zero: y(0), average: y(p.Average())}
+
+	for i := 0; ; i++ {
+		price := lo + float64(i)*step
+		if price > hi+step/2 {
+			break
+		}
+
+		l.gridLines = append(l.gridLines, gridLine{y: y(price), label: formatPrice(price)})
+	}
+
+	slotWidth := float64(plot.Dx()) / float64(p.Len())
+	gap := max(1, int(slotWidth/8))
+
+	for i, s := range p.All() {
+		x0 := plot.Min.X + int(math.Round(float64(i)*slotWidth)) + gap
+		x1 := plot.Min.X + int(math.Round(float64(i+1)*slotWidth)) - gap
+
+		c := bar
+		switch s.Price {
+		case p.High():
+			c = high
+		case p.Low():
+			c = low
+		}
+
+		top, bottom := y(s.Price), l.zero
+		if top > bottom {
+			top, bottom = bottom, top
+		}
+
+		b := barShape{rect: image.Rect(x0, top, x1, max(bottom, top+1)), color: c}
+
+		if s.Start.Minute() == 0 && s.Start.Hour()%3 == 0 {
+			b.label = s.Start.Format("15")
+		}
+
+		l.bars = append(l.bars, b)
+	}
+
+	return l
+}
+
+// Draw draws a bar chart with one bar per slot of p. The highest and lowest bars are coloured, and a dashed line marks
+// the average price.
+func Draw(p *prices.Prices) *image.RGBA {
+	l := newLayout(p)
+
+	img := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+
+	for _, g := range l.gridLines {
+		fill(img, image.Rect(l.plot.Min.X, g.y, l.plot.Max.X, g.y+1), grid)
+		drawText(img, g.label, l.plot.Min.X-textWidth(g.label, textScale)-12, g.y-glyphHeight*textScale/2, textScale, text)
+	}
+
+	for _, b := range l.bars {
+		fill(img, b.rect, b.color)
+
+		if b.label != "" {
+			drawText(img, b.label, b.rect.Min.X+(b.rect.Dx()-textWidth(b.label, textScale))/2, l.plot.Max.Y+16, textScale, text)
+		}
+	}
+
+	// Axes.
+	fill(img, image.Rect(l.plot.Min.X, l.plot.Min.Y, l.plot.Min.X+2, l.plot.Max.Y), axis)
+	fill(img, image.Rect(l.plot.Min.X, l.zero, l.plot.Max.X, l.zero+2), axis)
+
+	// Dashed average line.
+	for x := l.plot.Min.X; x < l.plot.Max.X; x += 24 {
+		fill(img, image.Rect(x, l.average-1, min(x+14, l.plot.Max.X), l.average+2), axis)
+	}
+
+	return img
+}
+
+// PNG draws the chart and encodes it as a PNG image.
+func PNG(p *prices.Prices) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := png.Encode(&buf, Draw(p)); err != nil {
+		return nil, fmt.Errorf("chart: encode png: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// scale returns the range of the price axis and the distance between its grid lines. The range always includes zero,
+// so that the bars start at zero.
+func scale(low, high float64) (lo, hi, step float64) {
+	low, high = min(low, 0), max(high, 0)
+
+	if high-low < 0.01 {
+		high = low + 0.01
+	}
+
+	for _, step = range []float64{0.01, 0.02, 0.05, 0.1, 0.2, 0.5, 1, 2, 5} {
+		if (high-low)/step <= 6 {
+			break
+		}
+	}
+
+	return math.Floor(low/step) * step, math.Ceil(high/step) * step, step
+}
+
+// formatPrice formats a price for the price axis, such as "0,25" or "-0,05".
+func formatPrice(price float64) string {
+	s := strconv.FormatFloat(math.Round(price*100)/100, 'f', 2, 64)
+	if s == "-0.00" {
+		s = "0.00"
+	}
+
+	return strings.Replace(s, ".", ",", 1)
+}
+
+func fill(img *image.RGBA, r image.Rectangle, c color.Color) {
+	draw.Draw(img, r, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+func mustParseColor(hex string) color.RGBA {
+	var c color.RGBA
+
+	if _, err := fmt.Sscanf(hex, "#%02x%02x%02x", &c.R, &c.G, &c.B); err != nil {
+		panic(fmt.Sprintf("chart: parse color %q: %v", hex, err))
+	}
+
+	c.A = 0xff
+
+	return c
+}
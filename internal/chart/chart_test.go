@@ -0,0 +1,136 @@
+package chart
+
+import (
+	"bytes"
+	"flag"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/heyajulia/savvy/internal/datetime"
+	"github.com/heyajulia/savvy/internal/prices"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// sampleDay is a typical spring day: cheap at night, very cheap (even negative) around midday because of solar power,
+// and expensive in the evening.
+var sampleDay = []float64{
+	0.085, 0.078, 0.072, 0.07, 0.071, 0.079, 0.095, 0.11, 0.09, 0.05, 0.01, -0.01,
+	-0.02, -0.015, 0.0, 0.03, 0.07, 0.12, 0.165, 0.18, 0.15, 0.12, 0.1, 0.09,
+}
+
+func TestPNGGolden(t *testing.T) {
+	got, err := PNG(springDay(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := golden(t, "spring_day.png", got)
+
+	// Compare pixels rather than bytes, so that changes to the PNG encoder don't break the test.
+	gotImg, err := png.Decode(bytes.NewReader(got))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantImg, err := png.Decode(bytes.NewReader(want))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if gotImg.Bounds() != wantImg.Bounds() {
+		t.Fatalf("got bounds %v, want %v", gotImg.Bounds(), wantImg.Bounds())
+	}
+
+	if diff := countDifferentPixels(gotImg, wantImg); diff > 0 {
+		t.Errorf("%d pixels differ from the golden file (run with -update to accept the changes)", diff)
+	}
+}
+
+func TestSVGGolden(t *testing.T) {
+	got := SVG(springDay(t))
+
+	if want := golden(t, "spring_day.svg", got); !bytes.Equal(got, want) {
+		t.Errorf("SVG differs from the golden file (run with -update to accept the changes)")
+	}
+}
+
+func springDay(t *testing.T) *prices.Prices {
+	t.Helper()
+
+	loc := datetime.Location()
+
+	tariff := prices.Tariff{EnergyTax: 0.10154, PurchaseFee: 0.014876, VAT: 0.21}
+
+	return prices.New(time.Date(2025, time.April, 12, 0, 0, 0, 0, loc), time.Hour, sampleDay, tariff)
+}
+
+// golden returns the contents of the golden file with the given name. With -update, it first overwrites the file with
+// got.
+func golden(t *testing.T, name string, got []byte) []byte {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+
+	if *update {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file (run with -update to create it): %v", err)
+	}
+
+	return want
+}
+
+func TestDrawFlatDay(t *testing.T) {
+	p := prices.New(time.Date(2025, time.April, 12, 0, 0, 0, 0, time.UTC), time.Hour, make([]float64, 24), prices.Wholesale)
+
+	if img := Draw(p); img.Bounds() != image.Rect(0, 0, Width, Height) {
+		t.Errorf("got bounds %v", img.Bounds())
+	}
+}
+
+func TestScale(t *testing.T) {
+	tests := []struct {
+		low, high    float64
+		lo, hi, step float64
+	}{
+		{0.12, 0.34, 0, 0.4, 0.1},
+		{-0.05, 0.25, -0.05, 0.25, 0.05},
+		{0.2, 0.2, 0, 0.2, 0.05},
+		{0, 0, 0, 0.01, 0.01},
+	}
+
+	for _, tt := range tests {
+		lo, hi, step := scale(tt.low, tt.high)
+		if !near(lo, tt.lo) || !near(hi, tt.hi) || !near(step, tt.step) {
+			t.Errorf("scale(%v, %v): got %v, %v, %v, want %v, %v, %v", tt.low, tt.high, lo, hi, step, tt.lo, tt.hi, tt.step)
+		}
+	}
+}
+
+func near(a, b float64) bool {
+	return a-b < 1e-9 && b-a < 1e-9
+}
+
+func countDifferentPixels(a, b image.Image) int {
+	n := 0
+
+	for y := a.Bounds().Min.Y; y < a.Bounds().Max.Y; y++ {
+		for x := a.Bounds().Min.X; x < a.Bounds().Max.X; x++ {
+			if a.At(x, y) != b.At(x, y) {
+				n++
+			}
+		}
+	}
+
+	return n
+}
@@ -0,0 +1,58 @@
+package chart
+
+import (
+	"image"
+	"image/color"
+)
+
+// glyphs is a tiny bitmap font, just big enough for the chart's axis labels. Every glyph is 3 pixels wide and 5 pixels
+// high.
+var glyphs = map[rune][5]string{
+	'0': {"###", "#.#", "#.#", "#.#", "###"},
+	'1': {".#.", "##.", ".#.", ".#.", "###"},
+	'2': {"###", "..#", "###", "#..", "###"},
+	'3': {"###", "..#", "###", "..#", "###"},
+	'4': {"#.#", "#.#", "###", "..#", "..#"},
+	'5': {"###", "#..", "###", "..#", "###"},
+	'6': {"###", "#..", "###", "#.#", "###"},
+	'7': {"###", "..#", "..#", "..#", "..#"},
+	'8': {"###", "#.#", "###", "#.#", "###"},
+	'9': {"###", "#.#", "###", "..#", "###"},
+	',': {"...", "...", "...", ".#.", "#.."},
+	'-': {"...", "...", "###", "...", "..."},
+	':': {"...", ".#.", "...", ".#.", "..."},
+}
+
+const (
+	glyphWidth  = 3
+	glyphHeight = 5
+)
+
+// textWidth returns the width of s in pixels when drawn at the given scale.
+func textWidth(s string, scale int) int {
+	n := len([]rune(s))
+	if n == 0 {
+		return 0
+	}
+
+	return (n*(glyphWidth+1) - 1) * scale
+}
+
+// drawText draws s with its top left corner at (x, y). Characters without a glyph are drawn as spaces.
+func drawText(img *image.RGBA, s string, x, y, scale int, c color.Color) {
+	for _, r := range s {
+		if g, ok := glyphs[r]; ok {
+			for row, line := range g {
+				for col, px := range line {
+					if px != '#' {
+						continue
+					}
+
+					fill(img, image.Rect(x+col*scale, y+row*scale, x+(col+1)*scale, y+(row+1)*scale), c)
+				}
+			}
+		}
+
+		x += (glyphWidth + 1) * scale
+	}
+}
@@ -0,0 +1,53 @@
+package chart
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image/color"
+
+	"github.com/heyajulia/savvy/internal/prices"
+)
+
+// SVG draws the same chart as Draw, as a standalone SVG image. Unlike the PNG image, it scales to any size, and its
+// title is the chart's alt text.
+func SVG(p *prices.Prices) []byte {
+	l := newLayout(p)
+
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" role="img" font-family="sans-serif" font-size="22">`+"\n", Width, Height, Width, Height)
+	fmt.Fprintf(&b, "<title>%s</title>\n", html.EscapeString(AltText(p)))
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", hex(background))
+
+	for _, g := range l.gridLines {
+		fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s"/>`+"\n", l.plot.Min.X, g.y, l.plot.Max.X, g.y, hex(grid))
+		fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end" dominant-baseline="middle" fill="%s">%s</text>`+"\n", l.plot.Min.X-12, g.y, hex(text), g.label)
+	}
+
+	for _, bar := range l.bars {
+		r := bar.rect
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n", r.Min.X, r.Min.Y, r.Dx(), r.Dy(), hex(bar.color))
+
+		if bar.label != "" {
+			fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="middle" dominant-baseline="hanging" fill="%s">%s</text>`+"\n", r.Min.X+r.Dx()/2, l.plot.Max.Y+16, hex(text), bar.label)
+		}
+	}
+
+	line(&b, l.plot.Min.X+1, l.plot.Min.Y, l.plot.Min.X+1, l.plot.Max.Y, `stroke-width="2"`)
+	line(&b, l.plot.Min.X, l.zero+1, l.plot.Max.X, l.zero+1, `stroke-width="2"`)
+	line(&b, l.plot.Min.X, l.average, l.plot.Max.X, l.average, `stroke-width="3" stroke-dasharray="14 10"`)
+
+	b.WriteString("</svg>\n")
+
+	return b.Bytes()
+}
+
+// line draws an axis-coloured line with the given extra attributes.
+func line(b *bytes.Buffer, x1, y1, x2, y2 int, attributes string) {
+	fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" %s/>`+"\n", x1, y1, x2, y2, hex(axis), attributes)
+}
+
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -2,8 +2,11 @@ package config
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 
+	"github.com/heyajulia/savvy/internal/mastodon"
 	"github.com/heyajulia/savvy/internal/telegram/chatid"
 	"github.com/sethvargo/go-envconfig"
 )
@@ -31,9 +34,49 @@ type BlueskyReport struct {
 	Password string `env:"PASSWORD, required"`
 }
 
-// Cronitor contains optional Cronitor monitoring configuration.
+// Mastodon contains optional Mastodon configuration. Reports are only posted to Mastodon when Server is set.
+type Mastodon struct {
+	Server     string `env:"SERVER"`
+	Token      string `env:"TOKEN"`
+	Visibility string `env:"VISIBILITY, default=public"`
+}
+
+func (m Mastodon) validate() error {
+	if m.Server != "" && m.Token == "" {
+		return errors.New("config: MA_TOKEN is required when MA_SERVER is set")
+	}
+
+	if !slices.Contains(mastodon.Visibilities, m.Visibility) {
+		return fmt.Errorf("config: MA_VISIBILITY: %w: %q", mastodon.ErrInvalidVisibility, m.Visibility)
+	}
+
+	return nil
+}
+
+// Cronitor contains optional Cronitor monitoring configuration. URL monitors the daily report, and SummaryURL the
+// weekly, monthly and yearly summaries, which run on a different schedule.
 type Cronitor struct {
-	URL string `env:"URL"`
+	URL        string `env:"URL"`
+	SummaryURL string `env:"SUMMARY_URL"`
+}
+
+// Source contains price source configuration. The energyzero source only provides hourly prices; QuarterHours needs a
+// quarter-hourly source such as entsoe.
+type Source struct {
+	Name        string  `env:"NAME, default=energyzero"`
+	Secondary   string  `env:"SECONDARY"`
+	Tolerance   float64 `env:"TOLERANCE, default=0.01"`
+	Strict      bool    `env:"STRICT, default=false"`
+	EntsoEToken string  `env:"ENTSOE_TOKEN"`
+}
+
+// Tariff contains the charges that are added to wholesale prices, in euros per kWh excluding VAT.
+type Tariff struct {
+	EnergyTax   map[int]float64 `env:"ENERGY_TAX"`
+	PurchaseFee float64         `env:"PURCHASE_FEE, default=0.014876"`
+	DeliveryFee float64         `env:"DELIVERY_FEE, default=0"`
+	VAT         bool            `env:"VAT, default=true"`
+	Wholesale   bool            `env:"WHOLESALE, default=false"`
 }
 
 // Serve contains configuration for the serve binary.
@@ -42,12 +85,81 @@ type Serve struct {
 	Bluesky  BlueskyBase  `env:", prefix=BS_"`
 }
 
+// Lookup contains the configuration that commands need to look up a day's prices: the source to fetch them from, the
+// tariff to apply, and optionally a history that days are read from before they are fetched.
+type Lookup struct {
+	Source     Source `env:", prefix=SRC_"`
+	Tariff     Tariff `env:", prefix=TF_"`
+	HistoryDir string `env:"HISTORY_DIR"`
+}
+
+// ReportOptions contains configuration that determines what the daily report looks like. It is shared by report,
+// preview and prices.
+type ReportOptions struct {
+	QuarterHours bool      `env:"QUARTER_HOURS, default=false"`
+	Tiers        []float64 `env:"TIERS"`
+}
+
 // Report contains configuration for the report binary.
 type Report struct {
+	Lookup
+	ReportOptions
 	Telegram TelegramReport `env:", prefix=TG_"`
 	Bluesky  BlueskyReport  `env:", prefix=BS_"`
+	Mastodon Mastodon       `env:", prefix=MA_"`
 	Cronitor Cronitor       `env:", prefix=CR_"`
 	StampDir string         `env:"STAMP_DIR, required"`
+
+	// FeedFile is the Atom feed that daily reports are added to. When empty, there is no feed.
+	FeedFile    string `env:"FEED_FILE"`
+	FeedEntries int    `env:"FEED_ENTRIES, default=30"`
+}
+
+func (c Report) validate() error {
+	return c.Mastodon.validate()
+}
+
+// Backfill contains configuration for the backfill command. Unlike for other commands, the history is required.
+type Backfill struct {
+	Lookup
+}
+
+func (c Backfill) validate() error {
+	if c.HistoryDir == "" {
+		return errors.New("config: HISTORY_DIR is required")
+	}
+
+	return nil
+}
+
+// Chart contains configuration for the chart command.
+type Chart struct {
+	Lookup
+}
+
+// Preview contains configuration for the preview command. Unlike Report, it needs no credentials, because nothing is
+// posted.
+type Preview struct {
+	Lookup
+	ReportOptions
+}
+
+// Prices contains configuration for the prices command. It lists prices the way the report would, so it shares the
+// report's options.
+type Prices struct {
+	Lookup
+	ReportOptions
+}
+
+// Calendar contains configuration for the calendar command.
+type Calendar struct {
+	Lookup
+	QuarterHours bool `env:"QUARTER_HOURS, default=false"`
+}
+
+// validator is implemented by configuration that needs checks beyond what envconfig's struct tags can express.
+type validator interface {
+	validate() error
 }
 
 // Read reads configuration from environment variables into the given type.
@@ -59,5 +171,12 @@ func Read[T any]() (T, error) {
 		return zero, fmt.Errorf("config: process config: %w", err)
 	}
 
+	if v, ok := any(c).(validator); ok {
+		if err := v.validate(); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+
 	return c, nil
 }
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/heyajulia/savvy/internal/mastodon"
+)
+
+func TestReadBackfillHistoryDir(t *testing.T) {
+	if _, err := Read[Backfill](); err == nil {
+		t.Error("expected error without HISTORY_DIR")
+	}
+
+	t.Setenv("HISTORY_DIR", t.TempDir())
+
+	cfg, err := Read[Backfill]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Source.Name != "energyzero" {
+		t.Errorf("got source %q, want the default energyzero", cfg.Source.Name)
+	}
+}
+
+func TestReadReportMastodon(t *testing.T) {
+	tests := []struct {
+		name       string
+		server     string
+		token      string
+		visibility string
+		wantErr    bool
+	}{
+		{name: "not configured"},
+		{name: "configured", server: "https://mastodon.nl", token: "secret", visibility: "unlisted"},
+		{name: "server without token", server: "https://mastodon.nl", wantErr: true},
+		{name: "invalid visibility", server: "https://mastodon.nl", token: "secret", visibility: "friends", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TG_TOKEN", "token")
+			t.Setenv("TG_CHAT_ID", "-1001234567890")
+			t.Setenv("BS_IDENTIFIER", "savvy.example")
+			t.Setenv("BS_PASSWORD", "password")
+			t.Setenv("STAMP_DIR", t.TempDir())
+
+			// An empty variable is still set, so leave it out to get the default.
+			for name, value := range map[string]string{"MA_SERVER": tt.server, "MA_TOKEN": tt.token, "MA_VISIBILITY": tt.visibility} {
+				if value != "" {
+					t.Setenv(name, value)
+				}
+			}
+
+			_, err := Read[Report]()
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("got error %v, want error: %v", err, tt.wantErr)
+			}
+
+			if tt.visibility == "friends" && !errors.Is(err, mastodon.ErrInvalidVisibility) {
+				t.Errorf("got error %v, want %v", err, mastodon.ErrInvalidVisibility)
+			}
+		})
+	}
+}
@@ -38,6 +38,11 @@ var replacer = strings.NewReplacer(
 	"December", "december",
 )
 
+// Location returns Amsterdam's time zone, in which days start and end.
+func Location() *time.Location {
+	return amsterdam
+}
+
 func Now() time.Time {
 	return time.Now().In(amsterdam)
 }
@@ -46,12 +51,41 @@ func Tomorrow(t time.Time) time.Time {
 	return t.AddDate(0, 0, 1)
 }
 
+// Local returns t in Amsterdam time.
+func Local(t time.Time) time.Time {
+	return t.In(amsterdam)
+}
+
+// Weekday returns the Dutch name of t's day of the week in Amsterdam, such as "maandag".
+func Weekday(t time.Time) string {
+	return replacer.Replace(t.In(amsterdam).Weekday().String())
+}
+
+// StartOfDay returns midnight at the start of t's day in Amsterdam.
+func StartOfDay(t time.Time) time.Time {
+	t = t.In(amsterdam)
+
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, amsterdam)
+}
+
 func Format(t time.Time) string {
 	const layout = "Monday 2 January 2006"
 
 	return replacer.Replace(t.Format(layout))
 }
 
+// MonthName returns the Dutch name of t's month, such as "maart".
+func MonthName(t time.Time) string {
+	return replacer.Replace(t.Month().String())
+}
+
+// FormatMonth formats t's month and year in Dutch, such as "maart 2025".
+func FormatMonth(t time.Time) string {
+	const layout = "January 2006"
+
+	return replacer.Replace(t.Format(layout))
+}
+
 func FormatRFC3339Milli(t time.Time) string {
 	// See https://go.dev/issue/36472 and issue #75 in this repo.
 	return t.Round(time.Millisecond).Format(time.RFC3339Nano)
@@ -45,6 +45,27 @@ func TestTomorrow(t *testing.T) {
 	}
 }
 
+func TestStartOfDay(t *testing.T) {
+	tests := []struct {
+		give string
+		want string
+	}{
+		{"2025-03-29T12:00:00+01:00", "2025-03-29T00:00:00+01:00"},
+		// Just after midnight in Amsterdam, but still the previous day in UTC.
+		{"2025-03-29T23:30:00Z", "2025-03-30T00:00:00+01:00"},
+		{"2025-10-26T23:59:59+01:00", "2025-10-26T00:00:00+02:00"},
+	}
+
+	for _, tt := range tests {
+		give, _ := time.Parse(time.RFC3339, tt.give)
+
+		got := StartOfDay(give).Format(time.RFC3339)
+		if got != tt.want {
+			t.Errorf("StartOfDay(%s) = %s, want %s", tt.give, got, tt.want)
+		}
+	}
+}
+
 func BenchmarkNow(b *testing.B) {
 	for b.Loop() {
 		_ = Now()
@@ -67,3 +88,30 @@ func newDateOnly(s string) time.Time {
 	t, _ := time.Parse(time.DateOnly, s)
 	return t
 }
+
+func TestWeekday(t *testing.T) {
+	got := Weekday(newDateOnly("2009-11-10"))
+	want := "dinsdag"
+
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestFormatMonth(t *testing.T) {
+	got := FormatMonth(newDateOnly("2009-11-10"))
+	want := "november 2009"
+
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestMonthName(t *testing.T) {
+	got := MonthName(newDateOnly("2009-11-10"))
+	want := "november"
+
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -1,65 +1,132 @@
 package internal
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
-	"net/http"
-	"net/url"
+	"log/slog"
+	"math"
 	"time"
 
+	"github.com/heyajulia/savvy/internal/datetime"
 	"github.com/heyajulia/savvy/internal/prices"
+	"github.com/heyajulia/savvy/internal/source"
 )
 
-var ErrPriceLength = errors.New("unexpected number of prices")
+var (
+	ErrPriceLength   = errors.New("unexpected number of prices")
+	ErrPriceMismatch = errors.New("sources disagree on prices")
+)
 
 const (
 	minHourlyPrices = 23
 	maxHourlyPrices = 25
+
+	minQuarterHourlyPrices = 4 * minHourlyPrices
+	maxQuarterHourlyPrices = 4 * maxHourlyPrices
 )
 
-func GetEnergyPrices() (*prices.Prices, error) {
-	u, err := url.Parse("https://api.energyzero.nl/v1/energyprices")
-	if err != nil {
-		return nil, fmt.Errorf("parse base url: %w", err)
-	}
+// Sources describes where GetEnergyPrices retrieves prices from.
+type Sources struct {
+	Primary source.Source
 
-	q, err := QueryParameters(time.Now())
-	if err != nil {
-		return nil, fmt.Errorf("prepare query parameters: %w", err)
-	}
+	// Secondary is optional. It is used when Primary fails, and to cross-validate Primary when both succeed.
+	Secondary source.Source
 
-	u.RawQuery = q.Encode()
+	// Tolerance is the largest difference in euros per kWh between the sources that is accepted for any hour.
+	Tolerance float64
 
-	resp, err := http.Get(u.String())
-	if err != nil {
-		return nil, fmt.Errorf("sending request: %w", err)
+	// Strict makes GetEnergyPrices refuse prices that differ by more than Tolerance, instead of only logging it.
+	Strict bool
+}
+
+// GetEnergyPrices retrieves the prices for the given day and applies the tariff to them. Depending on the source, the
+// prices are hourly or quarter-hourly.
+//
+// If the primary source fails or returns an unexpected number of prices, the secondary source is used instead. If both
+// sources succeed, their prices are compared and the primary source's prices are used.
+func GetEnergyPrices(ctx context.Context, sources Sources, tariff prices.Tariff, day time.Time) (*prices.Prices, error) {
+	primary, primaryErr := fetch(ctx, sources.Primary, day)
+
+	if sources.Secondary == nil {
+		if primaryErr != nil {
+			return nil, primaryErr
+		}
+
+		return primary.prices(day, tariff), nil
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	secondary, secondaryErr := fetch(ctx, sources.Secondary, day)
+
+	switch {
+	case primaryErr != nil && secondaryErr != nil:
+		return nil, fmt.Errorf("primary: %w; secondary: %w", primaryErr, secondaryErr)
+	case primaryErr != nil:
+		slog.Warn("primary source failed, using secondary source", slog.Any("err", primaryErr))
+
+		return secondary.prices(day, tariff), nil
+	case secondaryErr != nil:
+		slog.Warn("secondary source failed, prices not cross-validated", slog.Any("err", secondaryErr))
+
+		return primary.prices(day, tariff), nil
 	}
 
-	var e struct {
-		Prices []struct {
-			Price float64 `json:"price"`
-		} `json:"Prices"`
+	if err := compare(primary.hourly(), secondary.hourly(), sources.Tolerance); err != nil {
+		if sources.Strict {
+			return nil, err
+		}
+
+		slog.Warn("sources disagree, using primary source", slog.Any("err", err))
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&e); err != nil {
-		return nil, fmt.Errorf("decoding response body: %w", err)
+	return primary.prices(day, tariff), nil
+}
+
+// fetched holds the prices a source returned, along with their resolution.
+type fetched struct {
+	ps         []float64
+	resolution time.Duration
+}
+
+func (f fetched) prices(day time.Time, tariff prices.Tariff) *prices.Prices {
+	return prices.New(datetime.StartOfDay(day), f.resolution, f.ps, tariff)
+}
+
+func (f fetched) hourly() []float64 {
+	return prices.HourlyAverages(f.ps, f.resolution)
+}
+
+func fetch(ctx context.Context, src source.Source, day time.Time) (fetched, error) {
+	ps, err := src.Fetch(ctx, day)
+	if err != nil {
+		return fetched{}, fmt.Errorf("fetch prices: %w", err)
 	}
 
-	if n := len(e.Prices); n < minHourlyPrices || n > maxHourlyPrices {
-		return nil, fmt.Errorf("%w: got %d", ErrPriceLength, n)
+	var resolution time.Duration
+
+	switch n := len(ps); {
+	case n >= minHourlyPrices && n <= maxHourlyPrices:
+		resolution = time.Hour
+	case n >= minQuarterHourlyPrices && n <= maxQuarterHourlyPrices:
+		resolution = 15 * time.Minute
+	default:
+		return fetched{}, fmt.Errorf("%w: got %d", ErrPriceLength, n)
 	}
 
-	ps := make([]float64, len(e.Prices))
+	return fetched{ps: ps, resolution: resolution}, nil
+}
+
+// compare returns an error if the hourly prices a and b differ by more than tolerance for any hour.
+func compare(a, b []float64, tolerance float64) error {
+	if len(a) != len(b) {
+		return fmt.Errorf("%w: got %d and %d prices", ErrPriceMismatch, len(a), len(b))
+	}
 
-	for i, price := range e.Prices {
-		ps[i] = price.Price
+	for hour := range a {
+		if diff := math.Abs(a[hour] - b[hour]); diff > tolerance {
+			return fmt.Errorf("%w: hour %d differs by %.5f (%.5f vs %.5f)", ErrPriceMismatch, hour, diff, a[hour], b[hour])
+		}
 	}
 
-	return prices.New(ps), nil
+	return nil
 }
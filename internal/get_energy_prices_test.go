@@ -0,0 +1,154 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/heyajulia/savvy/internal/prices"
+	"github.com/heyajulia/savvy/internal/prices/pricestest"
+)
+
+type fakeSource struct {
+	prices []float64
+	err    error
+}
+
+func (f fakeSource) Fetch(ctx context.Context, day time.Time) ([]float64, error) {
+	return slices.Clone(f.prices), f.err
+}
+
+func TestGetEnergyPrices(t *testing.T) {
+	errBoom := errors.New("boom")
+	tariff := prices.Tariff{EnergyTax: 0.12, PurchaseFee: 0.02}
+
+	skewed := pricestest.Constant(24, 0.1)
+	skewed[7] = 0.2
+
+	tests := []struct {
+		name    string
+		sources Sources
+		// wantLow is the low price after charges, to tell the sources apart.
+		wantLow float64
+		wantErr error
+	}{
+		{
+			name:    "primary only",
+			sources: Sources{Primary: fakeSource{prices: pricestest.Constant(24, 0.1)}},
+			wantLow: 0.24,
+		},
+		{
+			name:    "primary only fails",
+			sources: Sources{Primary: fakeSource{err: errBoom}},
+			wantErr: errBoom,
+		},
+		{
+			name:    "primary only returns too few prices",
+			sources: Sources{Primary: fakeSource{prices: pricestest.Constant(12, 0.1)}},
+			wantErr: ErrPriceLength,
+		},
+		{
+			name: "primary fails, secondary succeeds",
+			sources: Sources{
+				Primary:   fakeSource{err: errBoom},
+				Secondary: fakeSource{prices: pricestest.Constant(24, 0.2)},
+			},
+			wantLow: 0.34,
+		},
+		{
+			name: "primary returns too few prices, secondary succeeds",
+			sources: Sources{
+				Primary:   fakeSource{prices: pricestest.Constant(22, 0.1)},
+				Secondary: fakeSource{prices: pricestest.Constant(24, 0.2)},
+			},
+			wantLow: 0.34,
+		},
+		{
+			name: "secondary fails",
+			sources: Sources{
+				Primary:   fakeSource{prices: pricestest.Constant(24, 0.1)},
+				Secondary: fakeSource{err: errBoom},
+			},
+			wantLow: 0.24,
+		},
+		{
+			name: "both fail",
+			sources: Sources{
+				Primary:   fakeSource{err: errBoom},
+				Secondary: fakeSource{prices: pricestest.Constant(26, 0.1)},
+			},
+			wantErr: ErrPriceLength,
+		},
+		{
+			name: "sources agree within tolerance",
+			sources: Sources{
+				Primary:   fakeSource{prices: pricestest.Constant(24, 0.1)},
+				Secondary: fakeSource{prices: pricestest.Constant(24, 0.105)},
+				Tolerance: 0.01,
+				Strict:    true,
+			},
+			wantLow: 0.24,
+		},
+		{
+			name:    "primary only, quarter hourly",
+			sources: Sources{Primary: fakeSource{prices: pricestest.Constant(96, 0.1)}},
+			wantLow: 0.24,
+		},
+		{
+			name:    "primary only returns too many prices",
+			sources: Sources{Primary: fakeSource{prices: pricestest.Constant(48, 0.1)}},
+			wantErr: ErrPriceLength,
+		},
+		{
+			name: "quarter hourly and hourly sources agree",
+			sources: Sources{
+				Primary:   fakeSource{prices: pricestest.Constant(96, 0.1)},
+				Secondary: fakeSource{prices: pricestest.Constant(24, 0.1)},
+				Tolerance: 0.01,
+				Strict:    true,
+			},
+			wantLow: 0.24,
+		},
+		{
+			name: "sources disagree, lenient",
+			sources: Sources{
+				Primary:   fakeSource{prices: pricestest.Constant(24, 0.1)},
+				Secondary: fakeSource{prices: skewed},
+				Tolerance: 0.01,
+			},
+			wantLow: 0.24,
+		},
+		{
+			name: "sources disagree, strict",
+			sources: Sources{
+				Primary:   fakeSource{prices: pricestest.Constant(24, 0.1)},
+				Secondary: fakeSource{prices: skewed},
+				Tolerance: 0.01,
+				Strict:    true,
+			},
+			wantErr: ErrPriceMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := GetEnergyPrices(context.Background(), tt.sources, tariff, time.Now())
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if p.Low() != tt.wantLow {
+				t.Errorf("got low %v, want %v", p.Low(), tt.wantLow)
+			}
+		})
+	}
+}
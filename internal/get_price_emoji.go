@@ -1,14 +1,16 @@
 package internal
 
-func GetPriceEmoji(price, average float64) string {
+import "github.com/heyajulia/savvy/internal/prices"
+
+// GetPriceEmoji returns the emoji for a price in the given tier. Free and negative prices get their own emoji,
+// regardless of their tier.
+func GetPriceEmoji(price float64, tier prices.Tier) string {
 	switch {
 	case price == 0:
 		return "🆓"
 	case price < 0:
 		return "💶"
-	case price <= average:
-		return "✅"
 	default:
-		return "❌"
+		return tier.Emoji()
 	}
 }
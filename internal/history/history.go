@@ -0,0 +1,166 @@
+// Package history stores the prices of past days on disk, so that reports can refer back to them and past days can be
+// analysed or re-rendered.
+//
+// Every day is stored in its own JSON file, named after the day (e.g. 2025-03-15.json). Besides the wholesale prices
+// and the tariff, which are all that is needed to reconstruct the prices, a file contains the all-in prices as they
+// were reported, so that the files are useful on their own.
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/heyajulia/savvy/internal/datetime"
+	"github.com/heyajulia/savvy/internal/prices"
+)
+
+var ErrNotFound = errors.New("history: no prices for day")
+
+// Store keeps one JSON file of prices per day in a directory.
+type Store struct {
+	dir string
+}
+
+func New(directory string) *Store {
+	return &Store{dir: directory}
+}
+
+type record struct {
+	Start      time.Time     `json:"start"`
+	Resolution time.Duration `json:"resolution"`
+	Tariff     prices.Tariff `json:"tariff"`
+	Wholesale  []float64     `json:"wholesale"`
+	Prices     []float64     `json:"prices"`
+}
+
+// Save stores p under the day it starts on. Prices that were saved for that day before are replaced.
+func (s *Store) Save(p *prices.Prices) error {
+	r := record{
+		Start:      p.Start(),
+		Resolution: p.Resolution(),
+		Tariff:     p.Tariff(),
+		Wholesale:  p.Wholesale(),
+		Prices:     make([]float64, 0, p.Len()),
+	}
+
+	for _, s := range p.All() {
+		r.Prices = append(r.Prices, s.Price)
+	}
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("history: marshal prices: %w", err)
+	}
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("history: create directory %q: %w", s.dir, err)
+	}
+
+	path := s.path(p.Start())
+
+	// Write to a temporary file first, so that a crash never leaves a partially written day behind.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0644); err != nil {
+		return fmt.Errorf("history: write file %q: %w", tmp, err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("history: rename file %q: %w", tmp, err)
+	}
+
+	return nil
+}
+
+// Load returns the prices that were saved for the given day, or ErrNotFound if there are none.
+func (s *Store) Load(day time.Time) (*prices.Prices, error) {
+	p, err := ReadFile(s.path(day))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, datetime.Local(day).Format(time.DateOnly))
+	}
+
+	return p, err
+}
+
+// ReadFile reads the prices from a single file in the history's format, such as a file copied out of a history. Only
+// the start, resolution, tariff and wholesale prices are needed; the all-in prices are calculated again.
+func ReadFile(path string) (*prices.Prices, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("history: read file %q: %w", path, err)
+	}
+
+	var r record
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, fmt.Errorf("history: unmarshal file %q: %w", path, err)
+	}
+
+	if r.Resolution <= 0 || len(r.Wholesale) == 0 {
+		return nil, fmt.Errorf("history: file %q has no prices", path)
+	}
+
+	// JSON only preserves the offset, but slots must be in Amsterdam time to be formatted correctly around DST changes.
+	return prices.New(datetime.Local(r.Start), r.Resolution, r.Wholesale, r.Tariff), nil
+}
+
+// Range returns the prices of the days from from up to and including to, in chronological order. Days without prices
+// are skipped.
+func (s *Store) Range(from, to time.Time) ([]*prices.Prices, error) {
+	var ps []*prices.Prices
+
+	for day := datetime.StartOfDay(from); !day.After(to); day = day.AddDate(0, 0, 1) {
+		p, err := s.Load(day)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		ps = append(ps, p)
+	}
+
+	return ps, nil
+}
+
+// Days returns the days that have prices, in chronological order.
+func (s *Store) Days() ([]time.Time, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("history: read directory %q: %w", s.dir, err)
+	}
+
+	var days []time.Time
+
+	// ReadDir sorts entries by name, which sorts the days chronologically.
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok {
+			continue
+		}
+
+		day, err := time.Parse(time.DateOnly, name)
+		if err != nil {
+			continue
+		}
+
+		days = append(days, datetime.StartOfDay(day))
+	}
+
+	return days, nil
+}
+
+// path returns the file of the given day. Days are Amsterdam days, whatever location day is in.
+func (s *Store) path(day time.Time) string {
+	return filepath.Join(s.dir, datetime.Local(day).Format(time.DateOnly)+".json")
+}
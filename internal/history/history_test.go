@@ -0,0 +1,176 @@
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/heyajulia/savvy/internal/datetime"
+	"github.com/heyajulia/savvy/internal/prices"
+)
+
+func TestSaveLoad(t *testing.T) {
+	loc := datetime.Location()
+
+	s := New(t.TempDir())
+
+	start := time.Date(2025, time.March, 15, 0, 0, 0, 0, loc)
+	tariff := prices.Tariff{EnergyTax: 0.1, PurchaseFee: 0.02, VAT: 0.21}
+	p := prices.New(start, 15*time.Minute, []float64{0.1, -0.05, 0.2, 0.15}, tariff)
+
+	if err := s.Save(p); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.Load(start)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !got.Start().Equal(start) || got.Resolution() != p.Resolution() || got.Tariff() != tariff {
+		t.Errorf("got start %v, resolution %v and tariff %+v", got.Start(), got.Resolution(), got.Tariff())
+	}
+
+	if !slices.EqualFunc(got.Slots(), p.Slots(), equalSlots) {
+		t.Errorf("got slots %v, want %v", got.Slots(), p.Slots())
+	}
+}
+
+func TestLoadOtherLocation(t *testing.T) {
+	loc := datetime.Location()
+
+	s := New(t.TempDir())
+
+	start := time.Date(2025, time.March, 15, 0, 0, 0, 0, loc)
+	if err := s.Save(prices.New(start, time.Hour, []float64{0.1}, prices.Wholesale)); err != nil {
+		t.Fatal(err)
+	}
+
+	// 23:30 UTC on 14 March is already 15 March in Amsterdam.
+	got, err := s.Load(time.Date(2025, time.March, 14, 23, 30, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !got.Start().Equal(start) {
+		t.Errorf("got prices starting at %v, want %v", got.Start(), start)
+	}
+}
+
+func TestLoadNotFound(t *testing.T) {
+	s := New(t.TempDir())
+
+	if _, err := s.Load(time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC)); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("got %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "day.json")
+
+	// A hand-written file only needs the wholesale prices and what is needed to place them in time.
+	data := `{"start":"2025-03-15T00:00:00+01:00","resolution":3600000000000,"tariff":{"EnergyTax":0.1},"wholesale":[0.1,0.2]}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := p.Slots(); len(got) != 2 || got[0].Price != 0.2 || got[1].Price != 0.3 || got[1].Start.Hour() != 1 {
+		t.Errorf("got slots %v", got)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"start":"2025-03-15T00:00:00+01:00"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ReadFile(path); err == nil {
+		t.Error("expected an error for a file without prices")
+	}
+}
+
+func equalSlots(a, b prices.Slot) bool {
+	return a.Start.Equal(b.Start) && a.End.Equal(b.End) && a.Price == b.Price
+}
+
+func TestSaveWritesAllInPrices(t *testing.T) {
+	dir := t.TempDir()
+	s := New(dir)
+
+	start := time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC)
+	if err := s.Save(prices.New(start, time.Hour, []float64{0.1, 0.2}, prices.Tariff{EnergyTax: 0.1})); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, "2025-03-15.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var r record
+	if err := json.Unmarshal(b, &r); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []float64{0.2, 0.3}; !slices.Equal(r.Prices, want) {
+		t.Errorf("got all-in prices %v, want %v", r.Prices, want)
+	}
+}
+
+func TestRangeAndDays(t *testing.T) {
+	loc := datetime.Location()
+
+	s := New(filepath.Join(t.TempDir(), "history"))
+
+	days, err := s.Days()
+	if err != nil || len(days) != 0 {
+		t.Fatalf("got %v and %v for a missing directory, want no days", days, err)
+	}
+
+	// Saved out of order, with a gap, and across the start of DST.
+	for _, d := range []int{31, 28, 29} {
+		start := time.Date(2025, time.March, d, 0, 0, 0, 0, loc)
+		if err := s.Save(prices.New(start, time.Hour, []float64{float64(d) / 100}, prices.Wholesale)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ps, err := s.Range(time.Date(2025, time.March, 29, 0, 0, 0, 0, loc), time.Date(2025, time.April, 2, 0, 0, 0, 0, loc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []float64
+	for _, p := range ps {
+		got = append(got, p.Average())
+	}
+
+	if want := []float64{0.29, 0.31}; !slices.Equal(got, want) {
+		t.Errorf("got averages %v, want %v", got, want)
+	}
+
+	days, err = s.Days()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gotDays []string
+	for _, d := range days {
+		if !d.Equal(time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, loc)) {
+			t.Errorf("day %v is not midnight in Amsterdam", d)
+		}
+
+		gotDays = append(gotDays, d.Format(time.DateOnly))
+	}
+
+	if want := []string{"2025-03-28", "2025-03-29", "2025-03-31"}; !slices.Equal(gotDays, want) {
+		t.Errorf("got days %v, want %v", gotDays, want)
+	}
+}
@@ -0,0 +1,105 @@
+// Package ical writes iCalendar (RFC 5545) calendars, which calendar apps can subscribe to.
+//
+// Event times are written in UTC, which every calendar app shows in its own time zone. This keeps them correct across
+// DST transitions without having to describe the time zone's rules in the calendar.
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// ContentType is the media type of a calendar, for serving it over HTTP.
+	ContentType = "text/calendar; charset=utf-8"
+
+	prodID = "-//heyajulia//savvy//NL"
+
+	// maxLineLength is the maximum length of a line in octets, excluding the line break.
+	maxLineLength = 75
+)
+
+// Calendar is a named collection of events.
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+// Event is something that happens from Start until (but not including) End.
+type Event struct {
+	// UID uniquely and permanently identifies the event, so that calendar apps update it rather than adding it again.
+	UID         string
+	Start, End  time.Time
+	Summary     string
+	Description string
+}
+
+// Marshal encodes the calendar. stamp is the time at which the calendar was created.
+func (c Calendar) Marshal(stamp time.Time) []byte {
+	var b bytes.Buffer
+
+	writeLine(&b, "BEGIN:VCALENDAR")
+	writeLine(&b, "VERSION:2.0")
+	writeLine(&b, "PRODID:"+prodID)
+	writeLine(&b, "CALSCALE:GREGORIAN")
+	writeLine(&b, "METHOD:PUBLISH")
+
+	if c.Name != "" {
+		writeLine(&b, "X-WR-CALNAME:"+escape(c.Name))
+	}
+
+	for _, e := range c.Events {
+		writeLine(&b, "BEGIN:VEVENT")
+		writeLine(&b, "UID:"+escape(e.UID))
+		writeLine(&b, "DTSTAMP:"+formatTime(stamp))
+		writeLine(&b, "DTSTART:"+formatTime(e.Start))
+		writeLine(&b, "DTEND:"+formatTime(e.End))
+		writeLine(&b, "SUMMARY:"+escape(e.Summary))
+
+		if e.Description != "" {
+			writeLine(&b, "DESCRIPTION:"+escape(e.Description))
+		}
+
+		writeLine(&b, "TRANSP:TRANSPARENT")
+		writeLine(&b, "END:VEVENT")
+	}
+
+	writeLine(&b, "END:VCALENDAR")
+
+	return b.Bytes()
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// escape escapes a TEXT value.
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+// writeLine writes a content line, folding it into lines of at most maxLineLength octets. Continuation lines start
+// with a space, which counts towards their length. Lines are never folded in the middle of a UTF-8 sequence.
+func writeLine(b *bytes.Buffer, line string) {
+	limit := maxLineLength
+
+	for len(line) > limit {
+		i := limit
+		for i > 0 && !utf8.RuneStart(line[i]) {
+			i--
+		}
+
+		b.WriteString(line[:i])
+		b.WriteString("\r\n ")
+
+		line = line[i:]
+		limit = maxLineLength - 1
+	}
+
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,86 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/heyajulia/savvy/internal/datetime"
+)
+
+func TestMarshal(t *testing.T) {
+	loc := datetime.Location()
+
+	c := Calendar{
+		Name: "Energieprijzen",
+		Events: []Event{
+			{
+				UID:         "20250330-low@savvy",
+				Start:       time.Date(2025, time.March, 30, 1, 0, 0, 0, loc),
+				End:         time.Date(2025, time.March, 30, 3, 0, 0, 0, loc), // one hour later, because of DST
+				Summary:     "Laagste prijs; € 0,10, daarna",
+				Description: "Regel 1\nRegel 2",
+			},
+		},
+	}
+
+	got := string(c.Marshal(time.Date(2025, time.March, 29, 14, 0, 0, 0, time.UTC)))
+
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//heyajulia//savvy//NL",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:Energieprijzen",
+		"BEGIN:VEVENT",
+		"UID:20250330-low@savvy",
+		"DTSTAMP:20250329T140000Z",
+		"DTSTART:20250330T000000Z",
+		"DTEND:20250330T010000Z",
+		`SUMMARY:Laagste prijs\; € 0\,10\, daarna`,
+		`DESCRIPTION:Regel 1\nRegel 2`,
+		"TRANSP:TRANSPARENT",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteLineFolds(t *testing.T) {
+	var b bytes.Buffer
+
+	// "é" is two octets, and must not be split.
+	line := "DESCRIPTION:" + strings.Repeat("é", 100)
+
+	writeLine(&b, line)
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+
+	var unfolded strings.Builder
+
+	for i, l := range lines {
+		if len(l) > maxLineLength {
+			t.Errorf("line %d is %d octets long", i, len(l))
+		}
+
+		if i > 0 {
+			if !strings.HasPrefix(l, " ") {
+				t.Fatalf("continuation line %d doesn't start with a space", i)
+			}
+
+			l = l[1:]
+		}
+
+		unfolded.WriteString(l)
+	}
+
+	if unfolded.String() != line {
+		t.Errorf("unfolding gave %q, want %q", unfolded.String(), line)
+	}
+}
@@ -0,0 +1,90 @@
+// Package mastodon posts statuses to a Mastodon server through its REST API.
+package mastodon
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+var ErrInvalidVisibility = errors.New("mastodon: invalid visibility")
+
+// Visibilities are the visibilities that a status can have, from most to least visible.
+var Visibilities = []string{"public", "unlisted", "private", "direct"}
+
+type client struct {
+	server, token string
+	http          *http.Client
+}
+
+// NewClient returns a client for the Mastodon server at the given URL, such as https://mastodon.nl, which
+// authenticates with an access token that has the write:statuses scope.
+func NewClient(server, token string) *client {
+	return &client{
+		server: strings.TrimSuffix(server, "/"),
+		token:  token,
+		http:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+type status struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// PostStatus posts a Dutch status with the given visibility, which must be one of Visibilities.
+func (c *client) PostStatus(text, visibility string) (*status, error) {
+	if !slices.Contains(Visibilities, visibility) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidVisibility, visibility)
+	}
+
+	form := url.Values{
+		"status":     {text},
+		"visibility": {visibility},
+		"language":   {"nl"},
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.server+"/api/v1/statuses", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("mastodon: create request: %w", err)
+	}
+
+	sum := sha256.Sum256([]byte(text))
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	// The server ignores a retried request with the same key, so that a status is never posted twice.
+	req.Header.Set("Idempotency-Key", hex.EncodeToString(sum[:]))
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("mastodon: send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Error string `json:"error"`
+		}
+
+		if err := json.NewDecoder(resp.Body).Decode(&e); err != nil || e.Error == "" {
+			e.Error = "no description"
+		}
+
+		return nil, fmt.Errorf("mastodon: post status: %s: %s", resp.Status, e.Error)
+	}
+
+	var s status
+	if err := json.NewDecoder(resp.Body).Decode(&s); err != nil {
+		return nil, fmt.Errorf("mastodon: decode body: %w", err)
+	}
+
+	return &s, nil
+}
@@ -0,0 +1,86 @@
+package mastodon
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// instance stands in for a Mastodon server. It accepts statuses from the holder of token, and records the last one.
+type instance struct {
+	token string
+	got   *http.Request
+}
+
+func (i *instance) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != "/api/v1/statuses" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer "+i.token {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"The access token is invalid"}`))
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	i.got = r
+
+	_, _ = w.Write([]byte(`{"id":"113","url":"https://mastodon.example/@energieprijzen/113"}`))
+}
+
+func TestPostStatus(t *testing.T) {
+	srv := &instance{token: "secret"}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	s, err := NewClient(ts.URL+"/", "secret").PostStatus("Energieprijzen #energie", "unlisted")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.ID != "113" || s.URL != "https://mastodon.example/@energieprijzen/113" {
+		t.Errorf("got status %+v", s)
+	}
+
+	r := srv.got
+
+	if got := r.PostForm.Get("status"); got != "Energieprijzen #energie" {
+		t.Errorf("got status text %q", got)
+	}
+
+	if got := r.PostForm.Get("visibility"); got != "unlisted" {
+		t.Errorf("got visibility %q", got)
+	}
+
+	if got := r.PostForm.Get("language"); got != "nl" {
+		t.Errorf("got language %q", got)
+	}
+
+	if r.Header.Get("Idempotency-Key") == "" {
+		t.Error("expected an idempotency key")
+	}
+}
+
+func TestPostStatusError(t *testing.T) {
+	ts := httptest.NewServer(&instance{token: "secret"})
+	defer ts.Close()
+
+	_, err := NewClient(ts.URL, "wrong").PostStatus("Hallo", "public")
+	if err == nil || !strings.Contains(err.Error(), "The access token is invalid") {
+		t.Errorf("got %v, want an error with the server's description", err)
+	}
+}
+
+func TestPostStatusInvalidVisibility(t *testing.T) {
+	if _, err := NewClient("https://mastodon.example", "secret").PostStatus("Hallo", "everyone"); !errors.Is(err, ErrInvalidVisibility) {
+		t.Errorf("got %v, want %v", err, ErrInvalidVisibility)
+	}
+}
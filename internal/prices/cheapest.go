@@ -0,0 +1,124 @@
+package prices
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+var (
+	ErrInvalidCount = errors.New("prices: invalid number of slots")
+	ErrNoSelection  = errors.New("prices: no selection satisfies the constraints")
+)
+
+// CheapestOptions constrains the slots that Cheapest selects. The zero value imposes no constraints.
+type CheapestOptions struct {
+	// Deadline excludes slots that end after it. The zero time means no deadline.
+	Deadline time.Time
+
+	// MinRun is the shortest time the selected slots may run for without interruption. It is rounded up to whole
+	// slots, like the duration passed to CheapestWindow. Zero means that any selection of slots is fine.
+	MinRun time.Duration
+}
+
+// Cheapest returns the n slots with the lowest total price, in chronological order. Unlike CheapestWindow, the slots
+// don't need to be contiguous, which suits loads that can be switched on and off, like a heat pump boiler or a home
+// battery. Every run of consecutive selected slots lasts at least opts.MinRun.
+func (p *Prices) Cheapest(n int, opts CheapestOptions) ([]Slot, error) {
+	slots := p.Slots()
+
+	if !opts.Deadline.IsZero() {
+		for len(slots) > 0 && slots[len(slots)-1].End.After(opts.Deadline) {
+			slots = slots[:len(slots)-1]
+		}
+	}
+
+	if n <= 0 || n > len(slots) {
+		return nil, fmt.Errorf("%w: %d out of %d available", ErrInvalidCount, n, len(slots))
+	}
+
+	minRun := 1
+	if opts.MinRun > 0 {
+		minRun = int((opts.MinRun + p.resolution - 1) / p.resolution)
+	}
+
+	selected := cheapest(slots, n, minRun)
+	if selected == nil {
+		return nil, fmt.Errorf("%w: %d slots with runs of at least %d", ErrNoSelection, n, minRun)
+	}
+
+	return selected, nil
+}
+
+// cheapest selects n slots with the lowest total price such that every run of consecutive selected slots is at least
+// minRun slots long, or returns nil if that is impossible. Of equally cheap selections, it prefers earlier slots.
+//
+// It uses dynamic programming over the slots, where the state is the number of slots that remain to be selected and the
+// length of the current run, capped at minRun. A run may only end (by skipping a slot, or at the end of the day) once
+// its length reaches minRun.
+func cheapest(slots []Slot, n, minRun int) []Slot {
+	inf := math.Inf(1)
+	canEnd := func(run int) bool { return run == 0 || run == minRun }
+
+	// cost[i][k][r] is the lowest cost of selecting k more slots from slots[i:], with a current run of length r.
+	cost := make([][][]float64, len(slots)+1)
+
+	for i := len(slots); i >= 0; i-- {
+		cost[i] = make([][]float64, n+1)
+
+		for k := range cost[i] {
+			cost[i][k] = make([]float64, minRun+1)
+
+			for r := range cost[i][k] {
+				c := inf
+
+				switch {
+				case i == len(slots):
+					if k == 0 && canEnd(r) {
+						c = 0
+					}
+				default:
+					if canEnd(r) {
+						c = cost[i+1][k][0]
+					}
+
+					if k > 0 {
+						c = min(c, slots[i].Price+cost[i+1][k-1][min(r+1, minRun)])
+					}
+				}
+
+				cost[i][k][r] = c
+			}
+		}
+	}
+
+	if math.IsInf(cost[0][n][0], 1) {
+		return nil
+	}
+
+	selected := make([]Slot, 0, n)
+	k, r := n, 0
+
+	for i, s := range slots {
+		if k == 0 && canEnd(r) {
+			break
+		}
+
+		// Select the slot whenever that is at least as cheap as skipping it, so that earlier slots win ties. The sums
+		// may differ slightly depending on the order of additions, hence the tolerance.
+		if k > 0 {
+			next := min(r+1, minRun)
+			if c := s.Price + cost[i+1][k-1][next]; c <= cost[i][k][r]+1e-9 {
+				selected = append(selected, s)
+				k, r = k-1, next
+
+				continue
+			}
+		}
+
+		r = 0
+	}
+
+	return selected
+}
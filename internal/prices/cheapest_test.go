@@ -0,0 +1,113 @@
+package prices
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCheapest(t *testing.T) {
+	start := time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		resolution time.Duration
+		prices     []float64
+		n          int
+		opts       CheapestOptions
+		want       []int
+	}{
+		{
+			name:       "cheapest slots",
+			resolution: time.Hour,
+			prices:     []float64{0.3, 0.1, 0.4, 0.2, 0.5, 0.05},
+			n:          3,
+			want:       []int{1, 3, 5},
+		},
+		{
+			name:       "earliest of equal slots",
+			resolution: time.Hour,
+			prices:     []float64{0.2, 0.1, 0.1, 0.2, 0.1, 0.1},
+			n:          3,
+			want:       []int{1, 2, 4},
+		},
+		{
+			name:       "deadline",
+			resolution: time.Hour,
+			prices:     []float64{0.3, 0.1, 0.4, 0.2, 0.5, 0.05},
+			n:          3,
+			opts:       CheapestOptions{Deadline: start.Add(5 * time.Hour)},
+			want:       []int{0, 1, 3},
+		},
+		{
+			name:       "minimum run",
+			resolution: time.Hour,
+			prices:     []float64{0.3, 0.1, 0.4, 0.2, 0.5, 0.05},
+			n:          4,
+			opts:       CheapestOptions{MinRun: 2 * time.Hour},
+			want:       []int{0, 1, 4, 5},
+		},
+		{
+			name:       "minimum run allows longer runs",
+			resolution: time.Hour,
+			prices:     []float64{0.9, 0.1, 0.1, 0.1, 0.9, 0.2, 0.9},
+			n:          3,
+			opts:       CheapestOptions{MinRun: 2 * time.Hour},
+			want:       []int{1, 2, 3},
+		},
+		{
+			name:       "quarter hours with rounded minimum run",
+			resolution: 15 * time.Minute,
+			prices:     []float64{0.1, 0.5, 0.5, 0.2, 0.2, 0.2, 0.5},
+			n:          3,
+			opts:       CheapestOptions{MinRun: 40 * time.Minute},
+			want:       []int{3, 4, 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(start, tt.resolution, tt.prices, Wholesale)
+
+			got, err := p.Cheapest(tt.n, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := make([]Slot, 0, len(tt.want))
+			for _, i := range tt.want {
+				want = append(want, p.slot(i))
+			}
+
+			if !slices.Equal(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCheapestErrors(t *testing.T) {
+	start := time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC)
+	p := New(start, time.Hour, []float64{0.1, 0.2, 0.3}, Wholesale)
+
+	tests := []struct {
+		name string
+		n    int
+		opts CheapestOptions
+		want error
+	}{
+		{"zero", 0, CheapestOptions{}, ErrInvalidCount},
+		{"too many", 4, CheapestOptions{}, ErrInvalidCount},
+		{"too many before deadline", 2, CheapestOptions{Deadline: start.Add(time.Hour)}, ErrInvalidCount},
+		{"run longer than selection", 2, CheapestOptions{MinRun: 3 * time.Hour}, ErrNoSelection},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := p.Cheapest(tt.n, tt.opts); !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
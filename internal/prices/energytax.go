@@ -0,0 +1,69 @@
+package prices
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrTaxRatesUnknown is returned for days after the last year in the table of tax rates, whose rates haven't been
+// added yet.
+var ErrTaxRatesUnknown = errors.New("prices: tax rates unknown")
+
+// TaxRate contains the taxes on electricity that are in force from a given date. EnergyTax and ODE are in euros per
+// kWh, excluding VAT, for the first consumption bracket (which is what households pay). VAT is a fraction.
+type TaxRate struct {
+	From      time.Time
+	EnergyTax float64
+
+	// ODE is the "Opslag Duurzame Energie- en Klimaattransitie", which was merged into the energy tax in 2023.
+	ODE float64
+
+	VAT float64
+}
+
+// taxRates must be sorted by From.
+var taxRates = []TaxRate{
+	{From: date(2019, time.January, 1), EnergyTax: 0.09863, ODE: 0.0189, VAT: 0.21},
+	{From: date(2020, time.January, 1), EnergyTax: 0.09770, ODE: 0.0273, VAT: 0.21},
+	{From: date(2021, time.January, 1), EnergyTax: 0.09428, ODE: 0.0300, VAT: 0.21},
+	{From: date(2022, time.January, 1), EnergyTax: 0.03679, ODE: 0.0305, VAT: 0.21},
+	// VAT on energy was temporarily lowered to 9% for the second half of 2022.
+	{From: date(2022, time.July, 1), EnergyTax: 0.03679, ODE: 0.0305, VAT: 0.09},
+	{From: date(2023, time.January, 1), EnergyTax: 0.12599, VAT: 0.21},
+	{From: date(2024, time.January, 1), EnergyTax: 0.10880, VAT: 0.21},
+	{From: date(2025, time.January, 1), EnergyTax: 0.10154, VAT: 0.21},
+	{From: date(2026, time.January, 1), EnergyTax: 0.09157, VAT: 0.21},
+}
+
+// TaxRateOn returns the tax rates in force on the given day. Only the day's date (in its own location) is taken into
+// account. Days after the last year in the table return an error wrapping ErrTaxRatesUnknown, rather than silently
+// reusing outdated rates.
+func TaxRateOn(day time.Time) (TaxRate, error) {
+	d := date(day.Year(), day.Month(), day.Day())
+
+	if last := lastTaxRate(); d.Year() > last.From.Year() {
+		return TaxRate{}, fmt.Errorf("%w for %s: the table ends in %d", ErrTaxRatesUnknown, d.Format(time.DateOnly), last.From.Year())
+	}
+
+	for i := len(taxRates) - 1; i >= 0; i-- {
+		if !d.Before(taxRates[i].From) {
+			return taxRates[i], nil
+		}
+	}
+
+	return TaxRate{}, fmt.Errorf("prices: no tax rates for %s", d.Format(time.DateOnly))
+}
+
+// TaxRatesUntil returns the first day after the table of tax rates: from then on, TaxRateOn returns ErrTaxRatesUnknown.
+func TaxRatesUntil() time.Time {
+	return date(lastTaxRate().From.Year()+1, time.January, 1)
+}
+
+func lastTaxRate() TaxRate {
+	return taxRates[len(taxRates)-1]
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
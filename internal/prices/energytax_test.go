@@ -0,0 +1,103 @@
+package prices
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/heyajulia/savvy/internal/datetime"
+)
+
+func TestTaxRateOn(t *testing.T) {
+	tests := []struct {
+		day       time.Time
+		energyTax float64
+		ode       float64
+		vat       float64
+	}{
+		{date(2019, time.January, 1), 0.09863, 0.0189, 0.21},
+		{date(2019, time.December, 31), 0.09863, 0.0189, 0.21},
+		{date(2020, time.January, 1), 0.09770, 0.0273, 0.21},
+		{date(2020, time.December, 31), 0.09770, 0.0273, 0.21},
+		{date(2021, time.January, 1), 0.09428, 0.0300, 0.21},
+		{date(2021, time.December, 31), 0.09428, 0.0300, 0.21},
+		{date(2022, time.January, 1), 0.03679, 0.0305, 0.21},
+		{date(2022, time.June, 30), 0.03679, 0.0305, 0.21},
+		{date(2022, time.July, 1), 0.03679, 0.0305, 0.09},
+		{date(2022, time.December, 31), 0.03679, 0.0305, 0.09},
+		{date(2023, time.January, 1), 0.12599, 0, 0.21},
+		{date(2023, time.December, 31), 0.12599, 0, 0.21},
+		{date(2024, time.January, 1), 0.10880, 0, 0.21},
+		{date(2024, time.December, 31), 0.10880, 0, 0.21},
+		{date(2025, time.January, 1), 0.10154, 0, 0.21},
+		{date(2025, time.December, 31), 0.10154, 0, 0.21},
+		{date(2026, time.January, 1), 0.09157, 0, 0.21},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.day.Format(time.DateOnly), func(t *testing.T) {
+			rate, err := TaxRateOn(tt.day)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if rate.EnergyTax != tt.energyTax || rate.ODE != tt.ode || rate.VAT != tt.vat {
+				t.Errorf("got %+v, want energy tax %v, ODE %v and VAT %v", rate, tt.energyTax, tt.ode, tt.vat)
+			}
+		})
+	}
+}
+
+func TestTaxRateOnUsesLocalDate(t *testing.T) {
+	loc := datetime.Location()
+
+	// This is still 31 December 2024 in UTC.
+	day := time.Date(2025, time.January, 1, 0, 30, 0, 0, loc)
+
+	rate, err := TaxRateOn(day)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rate.EnergyTax != 0.10154 {
+		t.Errorf("got energy tax %v, want 0.10154", rate.EnergyTax)
+	}
+}
+
+func TestTaxRateOnBeforeTable(t *testing.T) {
+	if _, err := TaxRateOn(date(2018, time.December, 31)); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestTaxRateOnAfterTable(t *testing.T) {
+	last := lastTaxRate().From.Year()
+
+	if _, err := TaxRateOn(date(last, time.December, 31)); err != nil {
+		t.Fatalf("got error for last year in table: %v", err)
+	}
+
+	if _, err := TaxRateOn(date(last+1, time.January, 1)); !errors.Is(err, ErrTaxRatesUnknown) {
+		t.Fatalf("got error %v, want %v", err, ErrTaxRatesUnknown)
+	}
+}
+
+func TestTaxRatesUntil(t *testing.T) {
+	until := TaxRatesUntil()
+
+	if _, err := TaxRateOn(until.AddDate(0, 0, -1)); err != nil {
+		t.Errorf("got error for the day before %s: %v", until.Format(time.DateOnly), err)
+	}
+
+	if _, err := TaxRateOn(until); !errors.Is(err, ErrTaxRatesUnknown) {
+		t.Errorf("got error %v for %s, want %v", err, until.Format(time.DateOnly), ErrTaxRatesUnknown)
+	}
+}
+
+func TestTaxRatesSorted(t *testing.T) {
+	for i := 1; i < len(taxRates); i++ {
+		if !taxRates[i-1].From.Before(taxRates[i].From) {
+			t.Errorf("tax rate %d (%s) is not after tax rate %d", i, taxRates[i].From.Format(time.DateOnly), i-1)
+		}
+	}
+}
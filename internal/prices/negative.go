@@ -0,0 +1,37 @@
+package prices
+
+// NegativePeriods returns the contiguous periods in which electricity is free or better: the price is at or below zero
+// after charges, or the wholesale price is negative. The latter matters to people who sell electricity back at the
+// wholesale price, even if buying it isn't free.
+func (p *Prices) NegativePeriods() []Window {
+	var (
+		periods []Window
+		current []Slot
+	)
+
+	flush := func() {
+		if len(current) == 0 {
+			return
+		}
+
+		sum := 0.0
+		for _, s := range current {
+			sum += s.Price
+		}
+
+		periods = append(periods, Window{Slots: current, Average: round(sum / float64(len(current)))})
+		current = nil
+	}
+
+	for i, s := range p.All() {
+		if s.Price <= 0 || p.wholesale[i] < 0 {
+			current = append(current, s)
+		} else {
+			flush()
+		}
+	}
+
+	flush()
+
+	return periods
+}
@@ -0,0 +1,42 @@
+package prices
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNegativePeriods(t *testing.T) {
+	start := time.Date(2025, time.May, 11, 0, 0, 0, 0, time.UTC)
+	tariff := Tariff{EnergyTax: 0.1}
+
+	tests := []struct {
+		name      string
+		wholesale []float64
+		want      [][2]int // start and end (exclusive) indexes
+	}{
+		{"none", []float64{0.1, 0.05, 0.2}, nil},
+		{"negative wholesale", []float64{0.1, -0.01, -0.02, 0.1}, [][2]int{{1, 3}}},
+		{"free after charges", []float64{-0.1, 0.1, -0.3, -0.2}, [][2]int{{0, 1}, {2, 4}}},
+		{"whole day", []float64{-0.3, -0.2}, [][2]int{{0, 2}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(start, time.Hour, tt.wholesale, tariff)
+
+			periods := p.NegativePeriods()
+			if len(periods) != len(tt.want) {
+				t.Fatalf("got %d periods, want %d", len(periods), len(tt.want))
+			}
+
+			for i, w := range periods {
+				wantStart := start.Add(time.Duration(tt.want[i][0]) * time.Hour)
+				wantEnd := start.Add(time.Duration(tt.want[i][1]) * time.Hour)
+
+				if !w.Start().Equal(wantStart) || !w.End().Equal(wantEnd) {
+					t.Errorf("period %d: got %v–%v, want %v–%v", i, w.Start(), w.End(), wantStart, wantEnd)
+				}
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"math"
 	"slices"
 	"strings"
+	"time"
 )
 
 func Format(price float64) string {
@@ -16,70 +17,183 @@ func Format(price float64) string {
 	return strings.Replace(fmt.Sprintf("€\u00a0%.2f", v), ".", ",", 1)
 }
 
-// Prices represents a collection of energy prices.
+// Slot is a price that applies from Start until (but not including) End.
+type Slot struct {
+	Start, End time.Time
+	Price      float64
+}
+
+// Prices represents a collection of consecutive energy prices, each of which applies for the same amount of time (the
+// resolution).
+//
+// Though we understand there to be 24 hourly prices (or 96 quarter-hourly prices) in practice, Prices doesn't enforce
+// this. Instead, it is up to the caller to ensure that the number of prices is correct. This might seem odd, but it
+// makes Prices somewhat more flexible, and it makes testing easier.
 //
-// Though we understand there to be 24 prices (one for each hour of the day) in practice, Prices doesn't enforce this.
-// Instead, it is up to the caller to ensure that the number of prices is correct. This might seem odd, but it makes
-// Prices somewhat more flexible, and it makes testing easier.
+// Despite their names, AverageHours, HighHours and LowHours return slots of the Prices' resolution, which are only hours
+// when the resolution is one hour.
 type Prices struct {
-	prices                            []float64
-	average, high, low                float64
+	start                             time.Time
+	resolution                        time.Duration
+	tariff                            Tariff
+	wholesale, prices, sorted         []float64
+	average, high, low, stdDev        float64
 	averageHours, highHours, lowHours []int
 }
 
-func New(prices []float64) *Prices {
-	for i, p := range prices {
-		// TODO: Perhaps we could let the caller decide whether to round or add charges to make testing easier.
-		prices[i] = round(addCharges(p))
+// New creates Prices from wholesale prices (in euros per kWh, excluding VAT) that apply for resolution each, the first
+// of which starts at start. The tariff's charges are added to every price. The slots' times are in start's location.
+func New(start time.Time, resolution time.Duration, wholesale []float64, tariff Tariff) *Prices {
+	prices := make([]float64, len(wholesale))
+
+	for i, w := range wholesale {
+		prices[i] = round(tariff.Apply(w))
 	}
 
 	p := new(Prices)
+	p.start = start
+	p.resolution = resolution
+	p.tariff = tariff
+	p.wholesale = slices.Clone(wholesale)
 	p.prices = prices
 	p.calculate()
 
 	return p
 }
 
+// HourlyAverages averages prices with a resolution of less than an hour into hourly prices. Prices with a resolution of
+// an hour or more are returned as is.
+func HourlyAverages(prices []float64, resolution time.Duration) []float64 {
+	n := int(time.Hour / resolution)
+	if n <= 1 {
+		return slices.Clone(prices)
+	}
+
+	hourly := make([]float64, 0, len(prices)/n)
+
+	for chunk := range slices.Chunk(prices, n) {
+		sum := 0.0
+		for _, p := range chunk {
+			sum += p
+		}
+
+		hourly = append(hourly, sum/float64(len(chunk)))
+	}
+
+	return hourly
+}
+
 func (p *Prices) calculate() {
 	p.average = calculateAverage(p.prices)
 	p.high = round(slices.Max(p.prices))
 	p.low = round(slices.Min(p.prices))
+	p.stdDev = calculateStdDev(p.prices)
+	p.sorted = sorted(p.prices)
 
 	p.averageHours = wherePriceIs(p.average, p.prices)
 	p.highHours = wherePriceIs(p.high, p.prices)
 	p.lowHours = wherePriceIs(p.low, p.prices)
 }
 
-func (p *Prices) All() iter.Seq2[int, float64] {
-	return slices.All(p.prices)
+func (p *Prices) All() iter.Seq2[int, Slot] {
+	return func(yield func(int, Slot) bool) {
+		for i := range p.prices {
+			if !yield(i, p.slot(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Slots returns all slots.
+func (p *Prices) Slots() []Slot {
+	slots := make([]Slot, 0, len(p.prices))
+
+	for _, s := range p.All() {
+		slots = append(slots, s)
+	}
+
+	return slots
 }
 
 func (p *Prices) Len() int {
 	return len(p.prices)
 }
 
+// Tariff returns the tariff that was applied to the wholesale prices.
+func (p *Prices) Tariff() Tariff {
+	return p.tariff
+}
+
+// Wholesale returns the wholesale prices, excluding any charges and VAT.
+func (p *Prices) Wholesale() []float64 {
+	return slices.Clone(p.wholesale)
+}
+
+// Start returns the start of the first slot.
+func (p *Prices) Start() time.Time {
+	return p.start
+}
+
+// End returns the end of the last slot.
+func (p *Prices) End() time.Time {
+	return p.start.Add(time.Duration(len(p.prices)) * p.resolution)
+}
+
+// Resolution returns the amount of time each price applies for.
+func (p *Prices) Resolution() time.Duration {
+	return p.resolution
+}
+
+// Hourly returns p with its prices averaged per hour. If p already has a resolution of an hour (or more), Hourly returns
+// p itself.
+func (p *Prices) Hourly() *Prices {
+	if p.resolution >= time.Hour {
+		return p
+	}
+
+	return New(p.start, time.Hour, HourlyAverages(p.wholesale, p.resolution), p.tariff)
+}
+
 func (p *Prices) Average() float64 {
 	return p.average
 }
 
-func (p *Prices) AverageHours() []int {
-	return p.averageHours
+func (p *Prices) AverageHours() []Slot {
+	return p.slots(p.averageHours)
 }
 
 func (p *Prices) High() float64 {
 	return p.high
 }
 
-func (p *Prices) HighHours() []int {
-	return p.highHours
+func (p *Prices) HighHours() []Slot {
+	return p.slots(p.highHours)
 }
 
 func (p *Prices) Low() float64 {
 	return p.low
 }
 
-func (p *Prices) LowHours() []int {
-	return p.lowHours
+func (p *Prices) LowHours() []Slot {
+	return p.slots(p.lowHours)
+}
+
+func (p *Prices) slot(i int) Slot {
+	// Adding durations, rather than using the wall clock, keeps the slots correct on days with a DST transition.
+	start := p.start.Add(time.Duration(i) * p.resolution)
+
+	return Slot{Start: start, End: start.Add(p.resolution), Price: p.prices[i]}
+}
+
+func (p *Prices) slots(indexes []int) []Slot {
+	slots := make([]Slot, 0, len(indexes))
+
+	for _, i := range indexes {
+		slots = append(slots, p.slot(i))
+	}
+
+	return slots
 }
 
 func calculateAverage(prices []float64) float64 {
@@ -104,15 +218,6 @@ func wherePriceIs(price float64, prices []float64) []int {
 	return hours
 }
 
-func addCharges(price float64) float64 {
-	const (
-		energyTax   = 0.1228634
-		purchaseFee = 0.018
-	)
-
-	return price + purchaseFee + energyTax
-}
-
 func round(price float64) float64 {
 	return math.Round(price*100) / 100
 }
@@ -0,0 +1,157 @@
+package prices
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/heyajulia/savvy/internal/datetime"
+)
+
+func TestHourlyAverages(t *testing.T) {
+	tests := []struct {
+		name       string
+		prices     []float64
+		resolution time.Duration
+		want       []float64
+	}{
+		{
+			name:       "hourly",
+			prices:     []float64{0.1, 0.2, 0.3},
+			resolution: time.Hour,
+			want:       []float64{0.1, 0.2, 0.3},
+		},
+		{
+			name:       "quarter hourly",
+			prices:     []float64{0.1, 0.1, 0.2, 0.2, 0.4, 0.4, 0.4, 0.4},
+			resolution: 15 * time.Minute,
+			want:       []float64{0.15000000000000002, 0.4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HourlyAverages(tt.prices, tt.resolution)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHourly(t *testing.T) {
+	start := time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC)
+	quarters := New(start, 15*time.Minute, []float64{0.1, 0.1, 0.2, 0.2, 0.5, 0.5, 0.5, 0.5, 0.3, 0.3, 0.3, 0.3}, Wholesale)
+
+	hourly := quarters.Hourly()
+
+	if hourly.Resolution() != time.Hour {
+		t.Errorf("got resolution %v, want %v", hourly.Resolution(), time.Hour)
+	}
+
+	if hourly.Len() != 3 {
+		t.Fatalf("got %d prices, want 3", hourly.Len())
+	}
+
+	wantHigh := []Slot{{Start: start.Add(time.Hour), End: start.Add(2 * time.Hour), Price: 0.5}}
+	if hourly.High() != 0.5 || !slices.Equal(hourly.HighHours(), wantHigh) {
+		t.Errorf("got high %v at %v, want 0.5 at %v", hourly.High(), hourly.HighHours(), wantHigh)
+	}
+
+	wantLow := []Slot{{Start: start, End: start.Add(time.Hour), Price: 0.15}}
+	if hourly.Low() != 0.15 || !slices.Equal(hourly.LowHours(), wantLow) {
+		t.Errorf("got low %v at %v, want 0.15 at %v", hourly.Low(), hourly.LowHours(), wantLow)
+	}
+
+	if same := hourly.Hourly(); same != hourly {
+		t.Error("expected Hourly to return hourly prices as is")
+	}
+}
+
+func TestSlots(t *testing.T) {
+	loc := datetime.Location()
+
+	tests := []struct {
+		name       string
+		start      time.Time
+		count      int
+		resolution time.Duration
+		want       []string
+	}{
+		{
+			name:       "regular day",
+			start:      time.Date(2024, time.March, 15, 0, 0, 0, 0, loc),
+			count:      4,
+			resolution: time.Hour,
+			want:       []string{"00:00", "01:00", "02:00", "03:00"},
+		},
+		{
+			name:       "dst start day loses hour",
+			start:      time.Date(2025, time.March, 30, 0, 0, 0, 0, loc),
+			count:      4,
+			resolution: time.Hour,
+			want:       []string{"00:00", "01:00", "03:00", "04:00"},
+		},
+		{
+			name:       "dst end day repeats hour",
+			start:      time.Date(2024, time.October, 27, 0, 0, 0, 0, loc),
+			count:      5,
+			resolution: time.Hour,
+			want:       []string{"00:00", "01:00", "02:00", "02:00", "03:00"},
+		},
+		{
+			name:       "dst start day loses quarter hours",
+			start:      time.Date(2025, time.March, 30, 1, 30, 0, 0, loc),
+			count:      4,
+			resolution: 15 * time.Minute,
+			want:       []string{"01:30", "01:45", "03:00", "03:15"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(tt.start, tt.resolution, make([]float64, tt.count), Wholesale)
+
+			var got []string
+			for i, slot := range p.All() {
+				if d := slot.End.Sub(slot.Start); d != tt.resolution {
+					t.Errorf("slot %d lasts %v, want %v", i, d, tt.resolution)
+				}
+
+				got = append(got, slot.Start.Format("15:04"))
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+
+			if !p.End().Equal(tt.start.Add(time.Duration(tt.count) * tt.resolution)) {
+				t.Errorf("unexpected end %v", p.End())
+			}
+		})
+	}
+}
+
+func TestNewAppliesTariff(t *testing.T) {
+	wholesale := []float64{0.1, -0.2}
+	tariff := Tariff{EnergyTax: 0.1, PurchaseFee: 0.02, VAT: 0.21}
+
+	p := New(time.Now(), time.Hour, wholesale, tariff)
+
+	var got []float64
+	for _, s := range p.All() {
+		got = append(got, s.Price)
+	}
+
+	if want := []float64{0.27, -0.1}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if !slices.Equal(p.Wholesale(), wholesale) {
+		t.Errorf("got wholesale %v, want %v", p.Wholesale(), wholesale)
+	}
+
+	if p.Tariff() != tariff {
+		t.Errorf("got tariff %+v, want %+v", p.Tariff(), tariff)
+	}
+}
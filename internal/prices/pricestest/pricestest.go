@@ -0,0 +1,12 @@
+// Package pricestest provides helpers for tests that need prices.
+package pricestest
+
+// Constant returns n prices that are all the same. Tests set the prices that matter to them afterwards.
+func Constant(n int, price float64) []float64 {
+	ps := make([]float64, n)
+	for i := range ps {
+		ps[i] = price
+	}
+
+	return ps
+}
@@ -0,0 +1,62 @@
+package prices
+
+import (
+	"math"
+	"slices"
+)
+
+// Median returns the median price.
+func (p *Prices) Median() float64 {
+	return p.Percentile(50)
+}
+
+// Percentile returns the price below which the given percentage (0–100) of prices fall, interpolating linearly between
+// the two nearest prices.
+func (p *Prices) Percentile(percent float64) float64 {
+	return round(percentile(p.sorted, percent))
+}
+
+// StdDev returns the (population) standard deviation of the prices.
+func (p *Prices) StdDev() float64 {
+	return p.stdDev
+}
+
+// Spread returns the difference between the highest and the lowest price. It is what a battery that charges at the
+// lowest price and discharges at the highest price earns per kWh, before losses.
+func (p *Prices) Spread() float64 {
+	return round(p.high - p.low)
+}
+
+func percentile(sorted []float64, percent float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+
+	rank := min(max(percent, 0), 100) / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(rank-float64(lo))
+}
+
+func calculateStdDev(prices []float64) float64 {
+	mean := 0.0
+	for _, v := range prices {
+		mean += v
+	}
+	mean /= float64(len(prices))
+
+	variance := 0.0
+	for _, v := range prices {
+		variance += (v - mean) * (v - mean)
+	}
+
+	return round(math.Sqrt(variance / float64(len(prices))))
+}
+
+func sorted(prices []float64) []float64 {
+	s := slices.Clone(prices)
+	slices.Sort(s)
+
+	return s
+}
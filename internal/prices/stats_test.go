@@ -0,0 +1,71 @@
+package prices
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	tests := []struct {
+		name   string
+		prices []float64
+		median float64
+		p10    float64
+		p90    float64
+		stdDev float64
+		spread float64
+	}{
+		{
+			name:   "odd count",
+			prices: []float64{0.3, 0.1, 0.5, 0.2, 0.4},
+			median: 0.3,
+			p10:    0.14,
+			p90:    0.46,
+			stdDev: 0.14,
+			spread: 0.4,
+		},
+		{
+			name:   "even count",
+			prices: []float64{0.1, 0.4, 0.2, 0.3},
+			median: 0.25,
+			p10:    0.13,
+			p90:    0.37,
+			stdDev: 0.11,
+			spread: 0.3,
+		},
+		{
+			name:   "flat",
+			prices: []float64{0.2, 0.2, 0.2},
+			median: 0.2,
+			p10:    0.2,
+			p90:    0.2,
+			stdDev: 0,
+			spread: 0,
+		},
+		{
+			name:   "negative",
+			prices: []float64{-0.2, 0.1, 0.4},
+			median: 0.1,
+			p10:    -0.14,
+			p90:    0.34,
+			stdDev: 0.24,
+			spread: 0.6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(time.Now(), time.Hour, tt.prices, Wholesale)
+
+			got := []float64{p.Median(), p.Percentile(10), p.Percentile(90), p.StdDev(), p.Spread()}
+			want := []float64{tt.median, tt.p10, tt.p90, tt.stdDev, tt.spread}
+			names := []string{"median", "p10", "p90", "stddev", "spread"}
+
+			for i := range got {
+				if got[i] != want[i] {
+					t.Errorf("%s: got %v, want %v", names[i], got[i], want[i])
+				}
+			}
+		})
+	}
+}
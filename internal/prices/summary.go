@@ -0,0 +1,101 @@
+package prices
+
+import (
+	"errors"
+	"math"
+)
+
+var ErrNoDays = errors.New("prices: no days to summarise")
+
+// Summary describes the prices of several days, such as a week or a month. It is based on hourly prices.
+type Summary struct {
+	Days    int
+	Average float64
+
+	// Cheapest and MostExpensive are the days with the lowest and highest average price.
+	Cheapest, MostExpensive *Prices
+
+	// NegativeHours is the number of hours in which electricity was free or better. See NegativePeriods.
+	NegativeHours int
+
+	// CheapestHour is the hour of the day (0–23) with the lowest average price over all days.
+	CheapestHour int
+
+	// Highest and Lowest are the hours with the highest and lowest price. When several hours share a price, the
+	// earliest one is used.
+	Highest, Lowest Slot
+
+	// HourAverages contains the average price for every hour of the day. Hours that never occurred (which can only
+	// happen when summarising a single day on which DST starts) are NaN.
+	HourAverages [24]float64
+}
+
+// Summarize summarises the prices of the given days.
+func Summarize(days []*Prices) (Summary, error) {
+	if len(days) == 0 {
+		return Summary{}, ErrNoDays
+	}
+
+	s := Summary{Days: len(days)}
+
+	var (
+		all         []float64
+		hourSums    [24]float64
+		hourCounts  [24]int
+		cheapestAvg = days[0].Hourly().Average()
+		highestAvg  = cheapestAvg
+	)
+
+	s.Cheapest, s.MostExpensive = days[0], days[0]
+	s.Highest, s.Lowest = days[0].Hourly().slot(0), days[0].Hourly().slot(0)
+
+	for _, day := range days {
+		hourly := day.Hourly()
+
+		for _, slot := range hourly.All() {
+			all = append(all, slot.Price)
+
+			if slot.Price > s.Highest.Price {
+				s.Highest = slot
+			}
+
+			if slot.Price < s.Lowest.Price {
+				s.Lowest = slot
+			}
+
+			// On the day DST ends, two slots start at the same wall-clock hour; both count towards it.
+			h := slot.Start.Hour()
+			hourSums[h] += slot.Price
+			hourCounts[h]++
+		}
+
+		for _, w := range hourly.NegativePeriods() {
+			s.NegativeHours += len(w.Slots)
+		}
+
+		if avg := hourly.Average(); avg < cheapestAvg {
+			s.Cheapest, cheapestAvg = day, avg
+		} else if avg > highestAvg {
+			s.MostExpensive, highestAvg = day, avg
+		}
+	}
+
+	s.Average = calculateAverage(all)
+
+	lowest := math.Inf(1)
+	for h := range hourSums {
+		if hourCounts[h] == 0 {
+			s.HourAverages[h] = math.NaN()
+			continue
+		}
+
+		avg := hourSums[h] / float64(hourCounts[h])
+		s.HourAverages[h] = round(avg)
+
+		if avg < lowest {
+			s.CheapestHour, lowest = h, avg
+		}
+	}
+
+	return s, nil
+}
@@ -0,0 +1,84 @@
+package prices
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSummarize(t *testing.T) {
+	start := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	day := func(offset int, ps ...float64) *Prices {
+		return New(start.AddDate(0, 0, offset), time.Hour, ps, Wholesale)
+	}
+
+	days := []*Prices{
+		day(0, 0.2, 0.1, 0.3),
+		day(1, 0.1, -0.1, 0.1),
+		day(2, 0.4, 0.3, 0.5),
+	}
+
+	s, err := Summarize(days)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Days != 3 {
+		t.Errorf("got %d days, want 3", s.Days)
+	}
+
+	if s.Average != 0.21 {
+		t.Errorf("got average %v, want 0.21", s.Average)
+	}
+
+	if s.Cheapest != days[1] || s.MostExpensive != days[2] {
+		t.Errorf("got cheapest day %v and most expensive day %v", s.Cheapest.Start(), s.MostExpensive.Start())
+	}
+
+	if s.NegativeHours != 1 {
+		t.Errorf("got %d negative hours, want 1", s.NegativeHours)
+	}
+
+	if s.CheapestHour != 1 {
+		t.Errorf("got cheapest hour %d, want 1", s.CheapestHour)
+	}
+
+	if !s.Highest.Start.Equal(start.AddDate(0, 0, 2).Add(2*time.Hour)) || s.Highest.Price != 0.5 {
+		t.Errorf("got highest hour %+v", s.Highest)
+	}
+
+	if !s.Lowest.Start.Equal(start.AddDate(0, 0, 1).Add(time.Hour)) || s.Lowest.Price != -0.1 {
+		t.Errorf("got lowest hour %+v", s.Lowest)
+	}
+
+	if got := s.HourAverages[:3]; got[0] != 0.23 || got[1] != 0.1 || got[2] != 0.3 {
+		t.Errorf("got hour averages %v, want [0.23 0.1 0.3]", got)
+	}
+
+	if !math.IsNaN(s.HourAverages[3]) {
+		t.Errorf("got average %v for an hour without prices, want NaN", s.HourAverages[3])
+	}
+}
+
+func TestSummarizeQuarterHours(t *testing.T) {
+	start := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+	p := New(start, 15*time.Minute, []float64{0.3, 0.3, 0.3, 0.3, -0.1, -0.1, 0.1, 0.1}, Wholesale)
+
+	s, err := Summarize([]*Prices{p})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The second hour averages to zero, which counts as free.
+	if s.NegativeHours != 1 || s.CheapestHour != 1 {
+		t.Errorf("got %d negative hours and cheapest hour %d, want 1 and 1", s.NegativeHours, s.CheapestHour)
+	}
+}
+
+func TestSummarizeNoDays(t *testing.T) {
+	if _, err := Summarize(nil); !errors.Is(err, ErrNoDays) {
+		t.Errorf("got %v, want %v", err, ErrNoDays)
+	}
+}
@@ -0,0 +1,76 @@
+package prices
+
+import (
+	"errors"
+	"time"
+)
+
+// Tariff describes the charges that are added to wholesale prices. All amounts are in euros per kWh, excluding VAT.
+type Tariff struct {
+	// EnergyTax is the energy tax ("energiebelasting").
+	EnergyTax float64
+
+	// ODE is the surcharge for sustainable energy, which was levied until 2023. See TaxRate.
+	ODE float64
+
+	// PurchaseFee is the supplier's purchase fee ("inkoopvergoeding").
+	PurchaseFee float64
+
+	// DeliveryFee is the supplier's fixed delivery fee, charged on top of the purchase fee.
+	DeliveryFee float64
+
+	// VAT is the VAT rate that is added to the price, including the charges. Zero means no VAT is added.
+	VAT float64
+}
+
+// Wholesale is the Tariff that adds nothing to wholesale prices.
+var Wholesale = Tariff{}
+
+// Apply returns the price that a consumer pays for the given wholesale price.
+func (t Tariff) Apply(wholesale float64) float64 {
+	price := wholesale + t.EnergyTax + t.ODE + t.PurchaseFee + t.DeliveryFee
+
+	return price * (1 + t.VAT)
+}
+
+// TariffSchedule describes a tariff whose taxes follow the built-in table of tax rates.
+type TariffSchedule struct {
+	// EnergyTax optionally maps years to the energy tax in that year, overriding the built-in table for the whole year.
+	// For years after the table, an override is required; the other rates are then taken from the table's last year.
+	EnergyTax map[int]float64
+
+	PurchaseFee float64
+	DeliveryFee float64
+
+	// VAT determines whether VAT is added, at the rate in force on the day.
+	VAT bool
+}
+
+// On returns the tariff that applies on the given day.
+func (s TariffSchedule) On(day time.Time) (Tariff, error) {
+	energyTax, override := s.EnergyTax[day.Year()]
+
+	rate, err := TaxRateOn(day)
+	if errors.Is(err, ErrTaxRatesUnknown) && override {
+		rate = lastTaxRate()
+	} else if err != nil {
+		return Tariff{}, err
+	}
+
+	t := Tariff{
+		EnergyTax:   rate.EnergyTax,
+		ODE:         rate.ODE,
+		PurchaseFee: s.PurchaseFee,
+		DeliveryFee: s.DeliveryFee,
+	}
+
+	if override {
+		t.EnergyTax = energyTax
+	}
+
+	if s.VAT {
+		t.VAT = rate.VAT
+	}
+
+	return t, nil
+}
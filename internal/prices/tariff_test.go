@@ -0,0 +1,100 @@
+package prices
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTariffApply(t *testing.T) {
+	tests := []struct {
+		name   string
+		tariff Tariff
+		give   float64
+		want   float64
+	}{
+		{"wholesale", Wholesale, 0.1, 0.1},
+		{"charges without VAT", Tariff{EnergyTax: 0.1, PurchaseFee: 0.02, DeliveryFee: 0.01}, 0.1, 0.23},
+		{"charges with VAT", Tariff{EnergyTax: 0.1, PurchaseFee: 0.02, VAT: 0.21}, 0.1, 0.2662},
+		{"negative price with VAT", Tariff{EnergyTax: 0.1, VAT: 0.21}, -0.3, -0.242},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tariff.Apply(tt.give); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTariffScheduleOn(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule TariffSchedule
+		day      time.Time
+		want     Tariff
+	}{
+		{
+			name:     "built-in table",
+			schedule: TariffSchedule{PurchaseFee: 0.02, VAT: true},
+			day:      time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+			want:     Tariff{EnergyTax: 0.10154, PurchaseFee: 0.02, VAT: 0.21},
+		},
+		{
+			name:     "ODE and lowered VAT",
+			schedule: TariffSchedule{VAT: true},
+			day:      time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC),
+			want:     Tariff{EnergyTax: 0.03679, ODE: 0.0305, VAT: 0.09},
+		},
+		{
+			name:     "without VAT",
+			schedule: TariffSchedule{},
+			day:      time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC),
+			want:     Tariff{EnergyTax: 0.1088},
+		},
+		{
+			name:     "override",
+			schedule: TariffSchedule{EnergyTax: map[int]float64{2025: 0.1}, VAT: true},
+			day:      time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC),
+			want:     Tariff{EnergyTax: 0.1, VAT: 0.21},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.schedule.On(tt.day)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTariffScheduleOnAfterTable(t *testing.T) {
+	day := time.Date(lastTaxRate().From.Year()+1, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, err := (TariffSchedule{VAT: true}).On(day); !errors.Is(err, ErrTaxRatesUnknown) {
+		t.Errorf("got error %v, want %v", err, ErrTaxRatesUnknown)
+	}
+
+	got, err := TariffSchedule{EnergyTax: map[int]float64{day.Year(): 0.095}, VAT: true}.On(day)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (Tariff{EnergyTax: 0.095, VAT: lastTaxRate().VAT}); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestTariffScheduleOnBeforeTable(t *testing.T) {
+	if _, err := (TariffSchedule{}).On(time.Date(2018, time.June, 1, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Error("expected error for day without tax rates")
+	}
+}
@@ -0,0 +1,102 @@
+package prices
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidThresholds = errors.New("prices: invalid thresholds")
+
+// Tier classifies a price relative to other prices.
+type Tier int
+
+const (
+	VeryCheap Tier = iota
+	Cheap
+	Normal
+	Expensive
+	VeryExpensive
+)
+
+var tiers = [...]struct {
+	name, label, emoji, color string
+}{
+	VeryCheap:     {"very cheap", "zeer goedkoop", "💚", "#1a9850"},
+	Cheap:         {"cheap", "goedkoop", "✅", "#91cf60"},
+	Normal:        {"normal", "normaal", "➖", "#fee08b"},
+	Expensive:     {"expensive", "duur", "❌", "#fc8d59"},
+	VeryExpensive: {"very expensive", "zeer duur", "🔥", "#d73027"},
+}
+
+// Tiers returns all tiers, from cheapest to most expensive.
+func Tiers() []Tier {
+	return []Tier{VeryCheap, Cheap, Normal, Expensive, VeryExpensive}
+}
+
+func (t Tier) String() string {
+	return tiers[t].name
+}
+
+// Label returns the Dutch name of the tier, for use in reports.
+func (t Tier) Label() string {
+	return tiers[t].label
+}
+
+func (t Tier) Emoji() string {
+	return tiers[t].emoji
+}
+
+// Color returns the tier's colour as a hexadecimal RGB value, such as "#1a9850". The colours run from green to red.
+func (t Tier) Color() string {
+	return tiers[t].color
+}
+
+// Thresholds separate the tiers. Prices below Cheap are cheap, and prices at or below VeryCheap are very cheap.
+// Likewise, prices above Expensive are expensive, and prices at or above VeryExpensive are very expensive. Everything
+// in between is normal.
+type Thresholds struct {
+	VeryCheap, Cheap, Expensive, VeryExpensive float64
+}
+
+// NewThresholds returns absolute thresholds in euros per kWh, which must be in ascending order: very cheap, cheap,
+// expensive and very expensive.
+func NewThresholds(bounds []float64) (Thresholds, error) {
+	if len(bounds) != 4 {
+		return Thresholds{}, fmt.Errorf("%w: got %d values, want 4", ErrInvalidThresholds, len(bounds))
+	}
+
+	for i := 1; i < len(bounds); i++ {
+		if bounds[i] < bounds[i-1] {
+			return Thresholds{}, fmt.Errorf("%w: %v is not in ascending order", ErrInvalidThresholds, bounds)
+		}
+	}
+
+	return Thresholds{VeryCheap: bounds[0], Cheap: bounds[1], Expensive: bounds[2], VeryExpensive: bounds[3]}, nil
+}
+
+// PercentileThresholds returns thresholds at the 10th, 30th, 70th and 90th percentiles of p, so that the tiers follow
+// the shape of the day rather than absolute prices. On a flat day, every price is normal.
+func (p *Prices) PercentileThresholds() Thresholds {
+	return Thresholds{
+		VeryCheap:     p.Percentile(10),
+		Cheap:         p.Percentile(30),
+		Expensive:     p.Percentile(70),
+		VeryExpensive: p.Percentile(90),
+	}
+}
+
+// Classify returns the tier that price falls in.
+func (t Thresholds) Classify(price float64) Tier {
+	switch {
+	case price < t.Cheap && price <= t.VeryCheap:
+		return VeryCheap
+	case price < t.Cheap:
+		return Cheap
+	case price > t.Expensive && price >= t.VeryExpensive:
+		return VeryExpensive
+	case price > t.Expensive:
+		return Expensive
+	default:
+		return Normal
+	}
+}
@@ -0,0 +1,75 @@
+package prices
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestThresholdsClassify(t *testing.T) {
+	thresholds := Thresholds{VeryCheap: 0.1, Cheap: 0.2, Expensive: 0.3, VeryExpensive: 0.4}
+
+	tests := []struct {
+		price float64
+		want  Tier
+	}{
+		{-0.1, VeryCheap},
+		{0.1, VeryCheap},
+		{0.15, Cheap},
+		{0.2, Normal},
+		{0.25, Normal},
+		{0.3, Normal},
+		{0.35, Expensive},
+		{0.4, VeryExpensive},
+		{1, VeryExpensive},
+	}
+
+	for _, tt := range tests {
+		if got := thresholds.Classify(tt.price); got != tt.want {
+			t.Errorf("%v: got %v, want %v", tt.price, got, tt.want)
+		}
+	}
+}
+
+func TestPercentileThresholds(t *testing.T) {
+	ps := []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1, 1.1}
+	p := New(time.Now(), time.Hour, ps, Wholesale)
+
+	thresholds := p.PercentileThresholds()
+
+	var got []Tier
+	for _, s := range p.All() {
+		got = append(got, thresholds.Classify(s.Price))
+	}
+
+	want := []Tier{VeryCheap, VeryCheap, Cheap, Normal, Normal, Normal, Normal, Normal, Expensive, VeryExpensive, VeryExpensive}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestPercentileThresholdsFlatDay(t *testing.T) {
+	p := New(time.Now(), time.Hour, []float64{0.2, 0.2, 0.2}, Wholesale)
+
+	if got := p.PercentileThresholds().Classify(0.2); got != Normal {
+		t.Errorf("got %v, want %v", got, Normal)
+	}
+}
+
+func TestNewThresholds(t *testing.T) {
+	got, err := NewThresholds([]float64{0, 0.1, 0.3, 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (Thresholds{VeryCheap: 0, Cheap: 0.1, Expensive: 0.3, VeryExpensive: 0.5}); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	for _, bounds := range [][]float64{nil, {0.1, 0.2, 0.3}, {0.1, 0.3, 0.2, 0.4}} {
+		if _, err := NewThresholds(bounds); !errors.Is(err, ErrInvalidThresholds) {
+			t.Errorf("%v: got %v, want %v", bounds, err, ErrInvalidThresholds)
+		}
+	}
+}
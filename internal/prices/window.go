@@ -0,0 +1,111 @@
+package prices
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+var (
+	ErrInvalidDuration = errors.New("prices: invalid duration")
+	ErrNotConsecutive  = errors.New("prices: prices are not consecutive")
+)
+
+// Window is a contiguous block of slots.
+type Window struct {
+	Slots   []Slot
+	Average float64
+}
+
+// Start returns the start of the window's first slot.
+func (w Window) Start() time.Time {
+	return w.Slots[0].Start
+}
+
+// End returns the end of the window's last slot.
+func (w Window) End() time.Time {
+	return w.Slots[len(w.Slots)-1].End
+}
+
+// Contiguous groups slots into windows of contiguous slots, such as the slots returned by LowHours. A slot that
+// doesn't start where the one before it ends starts a new window. The slots must be in chronological order.
+func Contiguous(slots []Slot) []Window {
+	var windows []Window
+
+	for i, s := range slots {
+		if i == 0 || !s.Start.Equal(slots[i-1].End) {
+			windows = append(windows, Window{})
+		}
+
+		w := &windows[len(windows)-1]
+		w.Slots = append(w.Slots, s)
+	}
+
+	for i := range windows {
+		sum := 0.0
+		for _, s := range windows[i].Slots {
+			sum += s.Price
+		}
+
+		windows[i].Average = round(sum / float64(len(windows[i].Slots)))
+	}
+
+	return windows
+}
+
+// CheapestWindow returns the contiguous block of slots that lasts at least d and has the lowest average price. A
+// duration that isn't a multiple of the resolution is rounded up, so a 90-minute window with hourly prices spans two
+// slots. When several windows are equally cheap, the earliest one is returned.
+func (p *Prices) CheapestWindow(d time.Duration) (Window, error) {
+	return cheapestWindow(p.Slots(), p.resolution, d)
+}
+
+// CheapestWindowAcross is like CheapestWindow, but it considers the prices of two consecutive days, so that the window
+// may cross midnight. The prices must have the same resolution, and tomorrow must start where today ends.
+func CheapestWindowAcross(today, tomorrow *Prices, d time.Duration) (Window, error) {
+	if today.resolution != tomorrow.resolution {
+		return Window{}, fmt.Errorf("%w: resolutions %v and %v differ", ErrNotConsecutive, today.resolution, tomorrow.resolution)
+	}
+
+	if !today.End().Equal(tomorrow.Start()) {
+		return Window{}, fmt.Errorf("%w: %s is not %s", ErrNotConsecutive, tomorrow.Start().Format(time.RFC3339), today.End().Format(time.RFC3339))
+	}
+
+	slots := slices.Concat(today.Slots(), tomorrow.Slots())
+
+	return cheapestWindow(slots, today.resolution, d)
+}
+
+func cheapestWindow(slots []Slot, resolution, d time.Duration) (Window, error) {
+	if d <= 0 {
+		return Window{}, fmt.Errorf("%w: %v", ErrInvalidDuration, d)
+	}
+
+	n := int((d + resolution - 1) / resolution)
+	if n > len(slots) {
+		return Window{}, fmt.Errorf("%w: %v is longer than the %d slots available", ErrInvalidDuration, d, len(slots))
+	}
+
+	sum := 0.0
+	for _, s := range slots[:n] {
+		sum += s.Price
+	}
+
+	best, bestSum := 0, sum
+
+	// Slide the window one slot at a time, keeping a running sum.
+	for i := 1; i+n <= len(slots); i++ {
+		sum += slots[i+n-1].Price - slots[i-1].Price
+
+		// The running sum drifts a little, so equal windows must not be mistaken for cheaper ones.
+		if sum < bestSum-1e-9 {
+			best, bestSum = i, sum
+		}
+	}
+
+	return Window{
+		Slots:   slices.Clone(slots[best : best+n]),
+		Average: round(bestSum / float64(n)),
+	}, nil
+}
@@ -0,0 +1,136 @@
+package prices
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheapestWindow(t *testing.T) {
+	start := time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		resolution time.Duration
+		prices     []float64
+		duration   time.Duration
+		wantStart  time.Time
+		wantEnd    time.Time
+		wantAvg    float64
+	}{
+		{
+			name:       "single slot",
+			resolution: time.Hour,
+			prices:     []float64{0.3, 0.1, 0.2},
+			duration:   time.Hour,
+			wantStart:  start.Add(time.Hour),
+			wantEnd:    start.Add(2 * time.Hour),
+			wantAvg:    0.1,
+		},
+		{
+			name:       "block beats single minimum",
+			resolution: time.Hour,
+			prices:     []float64{0.3, 0.05, 0.4, 0.1, 0.1, 0.1},
+			duration:   3 * time.Hour,
+			wantStart:  start.Add(3 * time.Hour),
+			wantEnd:    start.Add(6 * time.Hour),
+			wantAvg:    0.1,
+		},
+		{
+			name:       "rounds up to whole slots",
+			resolution: time.Hour,
+			prices:     []float64{0.3, 0.1, 0.1, 0.3},
+			duration:   90 * time.Minute,
+			wantStart:  start.Add(time.Hour),
+			wantEnd:    start.Add(3 * time.Hour),
+			wantAvg:    0.1,
+		},
+		{
+			name:       "quarter hours",
+			resolution: 15 * time.Minute,
+			prices:     []float64{0.2, 0.2, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.3},
+			duration:   90 * time.Minute,
+			wantStart:  start.Add(30 * time.Minute),
+			wantEnd:    start.Add(2 * time.Hour),
+			wantAvg:    0.1,
+		},
+		{
+			name:       "earliest of equal windows",
+			resolution: time.Hour,
+			prices:     []float64{0.1, 0.1, 0.3, 0.1, 0.1},
+			duration:   2 * time.Hour,
+			wantStart:  start,
+			wantEnd:    start.Add(2 * time.Hour),
+			wantAvg:    0.1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(start, tt.resolution, tt.prices, Wholesale)
+
+			w, err := p.CheapestWindow(tt.duration)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !w.Start().Equal(tt.wantStart) || !w.End().Equal(tt.wantEnd) || w.Average != tt.wantAvg {
+				t.Errorf("got %v–%v at %v, want %v–%v at %v", w.Start(), w.End(), w.Average, tt.wantStart, tt.wantEnd, tt.wantAvg)
+			}
+		})
+	}
+}
+
+func TestCheapestWindowInvalidDuration(t *testing.T) {
+	p := New(time.Now(), time.Hour, []float64{0.1, 0.2}, Wholesale)
+
+	for _, d := range []time.Duration{0, -time.Hour, 3 * time.Hour} {
+		if _, err := p.CheapestWindow(d); !errors.Is(err, ErrInvalidDuration) {
+			t.Errorf("%v: got %v, want %v", d, err, ErrInvalidDuration)
+		}
+	}
+}
+
+func TestCheapestWindowAcross(t *testing.T) {
+	start := time.Date(2025, time.March, 15, 20, 0, 0, 0, time.UTC)
+
+	today := New(start, time.Hour, []float64{0.3, 0.3, 0.2, 0.1}, Wholesale)
+	tomorrow := New(today.End(), time.Hour, []float64{0.1, 0.2, 0.3}, Wholesale)
+
+	w, err := CheapestWindowAcross(today, tomorrow, 2*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := start.Add(3 * time.Hour); !w.Start().Equal(want) || w.Average != 0.1 || len(w.Slots) != 2 {
+		t.Errorf("got %d slots from %v at %v, want 2 slots from %v at 0.1", len(w.Slots), w.Start(), w.Average, want)
+	}
+
+	gap := New(today.End().Add(time.Hour), time.Hour, []float64{0.1}, Wholesale)
+	if _, err := CheapestWindowAcross(today, gap, time.Hour); !errors.Is(err, ErrNotConsecutive) {
+		t.Errorf("got %v, want %v", err, ErrNotConsecutive)
+	}
+
+	quarters := New(today.End(), 15*time.Minute, []float64{0.1}, Wholesale)
+	if _, err := CheapestWindowAcross(today, quarters, time.Hour); !errors.Is(err, ErrNotConsecutive) {
+		t.Errorf("got %v, want %v", err, ErrNotConsecutive)
+	}
+}
+
+func TestContiguous(t *testing.T) {
+	p := New(time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC), time.Hour, []float64{0.1, 0.3, 0.1, 0.1, 0.2, 0.1}, Wholesale)
+
+	windows := Contiguous(p.LowHours())
+
+	if len(windows) != 3 {
+		t.Fatalf("got %d windows, want 3", len(windows))
+	}
+
+	if got := windows[1]; got.Start().Hour() != 2 || got.End().Hour() != 4 || len(got.Slots) != 2 || got.Average != 0.1 {
+		t.Errorf("got window from %v to %v with %d slots and average %v", got.Start(), got.End(), len(got.Slots), got.Average)
+	}
+
+	if got := Contiguous(nil); got != nil {
+		t.Errorf("got %v, want no windows", got)
+	}
+}
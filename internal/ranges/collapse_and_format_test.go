@@ -25,3 +25,23 @@ func TestCollapseAndFormat(t *testing.T) {
 		}
 	}
 }
+
+func TestCollapseAndFormatQuarters(t *testing.T) {
+	tests := []struct {
+		values []int
+		want   string
+	}{
+		{[]int{}, ""},
+		{[]int{0}, "van 00:00 tot 00:14"},
+		{[]int{53}, "van 13:15 tot 13:29"},
+		{[]int{52, 53, 54, 55}, "van 13:00 tot 13:59"},
+		{[]int{53, 54, 80, 95}, "van 13:15 tot 13:44, van 20:00 tot 20:14 en van 23:45 tot 23:59"},
+	}
+
+	for _, test := range tests {
+		actual := CollapseAndFormatQuarters(test.values)
+		if actual != test.want {
+			t.Errorf("CollapseAndFormatQuarters(%v) = %q, want %q", test.values, actual, test.want)
+		}
+	}
+}
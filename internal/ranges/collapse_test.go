@@ -30,3 +30,22 @@ func TestCollapse(t *testing.T) {
 		}
 	}
 }
+
+func TestCollapseQuarters(t *testing.T) {
+	tests := []struct {
+		values []int
+		want   []Range
+	}{
+		{[]int{}, nil},
+		{[]int{53}, []Range{SingleQuarter(53)}},
+		{[]int{52, 53, 54, 55}, []Range{NewQuarters(52, 55)}},
+		{[]int{0, 1, 94, 95}, []Range{NewQuarters(0, 1), NewQuarters(94, 95)}},
+	}
+
+	for _, test := range tests {
+		actual := CollapseQuarters(test.values)
+		if !slices.Equal(actual, test.want) {
+			t.Errorf("CollapseQuarters(%v) == %v, want %v", test.values, actual, test.want)
+		}
+	}
+}
@@ -1,17 +1,34 @@
 package ranges
 
 import (
+	"fmt"
 	"slices"
 	"strings"
 )
 
-const digits string = "000102030405060708091011121314151617181920212223"
+const digits string = "000102030405060708091011121314151617181920212223242526272829303132333435363738394041424344454647484950515253545556575859"
 
+// Range is a range of hours (0–23) or quarter hours (0–95) of the day.
 type Range struct {
 	start, end int
+	quarters   bool
 }
 
+// New returns the range of hours from start to end, inclusive.
 func New(start, end int) Range {
+	return newRange(start, end, 23)
+}
+
+// NewQuarters returns the range of quarter hours from start to end, inclusive. Quarter hour 0 starts at 00:00, and
+// quarter hour 95 starts at 23:45.
+func NewQuarters(start, end int) Range {
+	r := newRange(start, end, 95)
+	r.quarters = true
+
+	return r
+}
+
+func newRange(start, end, limit int) Range {
 	if start > end {
 		panic("New: start must be less than or equal to end")
 	}
@@ -20,8 +37,8 @@ func New(start, end int) Range {
 		panic("New: start and end must be positive")
 	}
 
-	if start > 23 || end > 23 {
-		panic("New: start and end must be less than or equal to 23")
+	if start > limit || end > limit {
+		panic(fmt.Sprintf("New: start and end must be less than or equal to %d", limit))
 	}
 
 	return Range{start: start, end: end}
@@ -31,7 +48,20 @@ func Single(value int) Range {
 	return New(value, value)
 }
 
+func SingleQuarter(value int) Range {
+	return NewQuarters(value, value)
+}
+
 func Collapse(values []int) []Range {
+	return collapse(values, New)
+}
+
+// CollapseQuarters is like Collapse, but for quarter hours.
+func CollapseQuarters(values []int) []Range {
+	return collapse(values, NewQuarters)
+}
+
+func collapse(values []int, newRange func(start, end int) Range) []Range {
 	var ranges []Range
 
 	if len(values) == 0 {
@@ -47,19 +77,19 @@ func Collapse(values []int) []Range {
 		value := values[i]
 
 		if value != end+1 {
-			ranges = append(ranges, New(start, end))
+			ranges = append(ranges, newRange(start, end))
 			start = value
 		}
 
 		end = value
 	}
 
-	ranges = append(ranges, New(start, end))
+	ranges = append(ranges, newRange(start, end))
 
 	return ranges
 }
 
-// Format formats the given ranges as a human-readable string of start and end hours.
+// Format formats the given ranges as a human-readable string of start and end times.
 func Format(ranges []Range) string {
 	var sb strings.Builder
 	sb.Grow(len(ranges) * 20)
@@ -74,10 +104,9 @@ func Format(ranges []Range) string {
 		}
 
 		sb.WriteString("van ")
-		writeHour(&sb, r.start)
-		sb.WriteString(":00 tot ")
-		writeHour(&sb, r.end)
-		sb.WriteString(":59")
+		writeTime(&sb, r.startMinute())
+		sb.WriteString(" tot ")
+		writeTime(&sb, r.endMinute())
 	}
 
 	return sb.String()
@@ -87,7 +116,36 @@ func CollapseAndFormat(values []int) string {
 	return Format(Collapse(values))
 }
 
-func writeHour(sb *strings.Builder, hour int) {
-	i := hour * 2
+// CollapseAndFormatQuarters is like CollapseAndFormat, but for quarter hours.
+func CollapseAndFormatQuarters(values []int) string {
+	return Format(CollapseQuarters(values))
+}
+
+// startMinute returns the minute of the day at which r starts.
+func (r Range) startMinute() int {
+	return r.start * r.width()
+}
+
+// endMinute returns the last minute of the day that r includes.
+func (r Range) endMinute() int {
+	return (r.end+1)*r.width() - 1
+}
+
+func (r Range) width() int {
+	if r.quarters {
+		return 15
+	}
+
+	return 60
+}
+
+func writeTime(sb *strings.Builder, minute int) {
+	writeTwoDigits(sb, minute/60)
+	sb.WriteByte(':')
+	writeTwoDigits(sb, minute%60)
+}
+
+func writeTwoDigits(sb *strings.Builder, n int) {
+	i := n * 2
 	sb.WriteString(digits[i : i+2])
 }
@@ -0,0 +1,74 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const energyZeroURL = "https://api.energyzero.nl/v1/energyprices"
+
+// Verify interface compliance.
+var _ Source = (*EnergyZero)(nil)
+
+// EnergyZero retrieves hourly prices from the EnergyZero API. It always asks for hourly prices, so quarter-hourly prices
+// need another source, such as EntsoE.
+type EnergyZero struct {
+	client  *http.Client
+	baseURL string
+}
+
+// NewEnergyZero creates a new EnergyZero source.
+func NewEnergyZero() *EnergyZero {
+	return &EnergyZero{client: http.DefaultClient, baseURL: energyZeroURL}
+}
+
+func (e *EnergyZero) Fetch(ctx context.Context, day time.Time) ([]float64, error) {
+	u, err := url.Parse(e.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("source: energyzero: parse base url: %w", err)
+	}
+
+	q, err := QueryParameters(day)
+	if err != nil {
+		return nil, fmt.Errorf("source: energyzero: prepare query parameters: %w", err)
+	}
+
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("source: energyzero: create request: %w", err)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("source: energyzero: sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("source: energyzero: unexpected status code: %d", resp.StatusCode)
+	}
+
+	var r struct {
+		Prices []struct {
+			Price float64 `json:"price"`
+		} `json:"Prices"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return nil, fmt.Errorf("source: energyzero: decoding response body: %w", err)
+	}
+
+	ps := make([]float64, len(r.Prices))
+
+	for i, price := range r.Prices {
+		ps[i] = price.Price
+	}
+
+	return ps, nil
+}
@@ -0,0 +1,64 @@
+package source
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestEnergyZeroFetch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("fromDate"); got != "2025-03-28T23:00:00Z" {
+			t.Errorf("unexpected fromDate %q", got)
+		}
+
+		w.Write([]byte(`{"Prices":[{"price":0.1},{"price":-0.05},{"price":0.25}]}`))
+	}))
+	defer srv.Close()
+
+	e := &EnergyZero{client: srv.Client(), baseURL: srv.URL}
+
+	ps, err := e.Fetch(context.Background(), time.Date(2025, time.March, 29, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []float64{0.1, -0.05, 0.25}; !slices.Equal(ps, want) {
+		t.Errorf("got %v, want %v", ps, want)
+	}
+}
+
+// EnergyZero is hourly-only by design: the report averages quarter-hourly prices from other sources, but never asks
+// EnergyZero for them.
+func TestEnergyZeroFetchHourly(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("interval"); got != "4" {
+			t.Errorf("got interval %q, want 4 (hourly)", got)
+		}
+
+		w.Write([]byte(`{"Prices":[]}`))
+	}))
+	defer srv.Close()
+
+	e := &EnergyZero{client: srv.Client(), baseURL: srv.URL}
+
+	if _, err := e.Fetch(context.Background(), time.Date(2025, time.March, 29, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEnergyZeroFetchStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	e := &EnergyZero{client: srv.Client(), baseURL: srv.URL}
+
+	if _, err := e.Fetch(context.Background(), time.Now()); err == nil {
+		t.Fatal("expected error")
+	}
+}
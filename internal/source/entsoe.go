@@ -0,0 +1,369 @@
+package source
+
+import (
+	"cmp"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/heyajulia/savvy/internal/datetime"
+)
+
+const (
+	entsoeURL = "https://web-api.tp.entsoe.eu/api"
+
+	// entsoeNetherlands is the EIC code of the Dutch bidding zone.
+	entsoeNetherlands = "10YNL----------L"
+
+	// entsoePeriodLayout is the layout of the periodStart and periodEnd query parameters.
+	entsoePeriodLayout = "200601021504"
+
+	// entsoeTimeLayout is the layout of the timestamps in market documents.
+	entsoeTimeLayout = "2006-01-02T15:04Z07:00"
+)
+
+var (
+	// ErrNoData is returned when ENTSO-E has no prices for the requested day (yet).
+	ErrNoData = errors.New("no data")
+
+	// ErrIncomplete is returned when a market document doesn't cover the requested day.
+	ErrIncomplete = errors.New("incomplete data")
+)
+
+// Verify interface compliance.
+var _ Source = (*EntsoE)(nil)
+
+// EntsoE retrieves day-ahead prices from the ENTSO-E Transparency Platform.
+type EntsoE struct {
+	token   string
+	client  *http.Client
+	baseURL string
+}
+
+// NewEntsoE creates a new EntsoE source that authenticates with the given security token.
+func NewEntsoE(token string) *EntsoE {
+	return &EntsoE{token: token, client: http.DefaultClient, baseURL: entsoeURL}
+}
+
+func (e *EntsoE) Fetch(ctx context.Context, day time.Time) ([]float64, error) {
+	start, end := dayBounds(day)
+
+	u, err := url.Parse(e.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("source: entsoe: parse base url: %w", err)
+	}
+
+	u.RawQuery = url.Values{
+		"securityToken": {e.token},
+		"documentType":  {"A44"},
+		"in_Domain":     {entsoeNetherlands},
+		"out_Domain":    {entsoeNetherlands},
+		"periodStart":   {start.UTC().Format(entsoePeriodLayout)},
+		"periodEnd":     {end.UTC().Format(entsoePeriodLayout)},
+	}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("source: entsoe: create request: %w", err)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("source: entsoe: sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// The API reports most errors as an acknowledgement document, which may come with any status code.
+	doc, err := parseMarketDocument(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("source: entsoe: status %d: %w", resp.StatusCode, err)
+	}
+
+	ps, err := doc.prices(start, end)
+	if err != nil {
+		return nil, fmt.Errorf("source: entsoe: %w", err)
+	}
+
+	return ps, nil
+}
+
+type publicationMarketDocument struct {
+	Type       string         `xml:"type"`
+	TimeSeries []entsoeSeries `xml:"TimeSeries"`
+}
+
+type entsoeSeries struct {
+	InDomain  string         `xml:"in_Domain.mRID"`
+	Currency  string         `xml:"currency_Unit.name"`
+	Unit      string         `xml:"price_Measure_Unit.name"`
+	CurveType string         `xml:"curveType"`
+	Periods   []entsoePeriod `xml:"Period"`
+}
+
+type entsoePeriod struct {
+	TimeInterval struct {
+		Start string `xml:"start"`
+		End   string `xml:"end"`
+	} `xml:"timeInterval"`
+	Resolution string `xml:"resolution"`
+	Points     []struct {
+		Position int     `xml:"position"`
+		Price    float64 `xml:"price.amount"`
+	} `xml:"Point"`
+}
+
+type acknowledgementMarketDocument struct {
+	Reasons []struct {
+		Code string `xml:"code"`
+		Text string `xml:"text"`
+	} `xml:"Reason"`
+}
+
+// sample is a price in euros per MWh, excluding VAT, that applies for resolution starting at start.
+type sample struct {
+	start      time.Time
+	resolution time.Duration
+	price      float64
+}
+
+func (s sample) end() time.Time {
+	return s.start.Add(s.resolution)
+}
+
+// parseMarketDocument parses a Publication_MarketDocument. Acknowledgement_MarketDocuments, which the API returns
+// instead when something is wrong, are turned into errors.
+func parseMarketDocument(r io.Reader) (*publicationMarketDocument, error) {
+	d := xml.NewDecoder(r)
+
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return nil, fmt.Errorf("read document: %w", err)
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "Publication_MarketDocument":
+			var doc publicationMarketDocument
+
+			if err := d.DecodeElement(&doc, &start); err != nil {
+				return nil, fmt.Errorf("decode publication document: %w", err)
+			}
+
+			if doc.Type != "A44" {
+				return nil, fmt.Errorf("unexpected document type %q", doc.Type)
+			}
+
+			return &doc, nil
+		case "Acknowledgement_MarketDocument":
+			var ack acknowledgementMarketDocument
+
+			if err := d.DecodeElement(&ack, &start); err != nil {
+				return nil, fmt.Errorf("decode acknowledgement document: %w", err)
+			}
+
+			reasons := make([]string, 0, len(ack.Reasons))
+			for _, reason := range ack.Reasons {
+				reasons = append(reasons, fmt.Sprintf("%s (%s)", reason.Text, reason.Code))
+			}
+
+			// Code 999 is used for every kind of error, but "no matching data" is the one we expect to see when
+			// prices haven't been published yet.
+			for _, reason := range ack.Reasons {
+				if strings.Contains(reason.Text, "No matching data found") {
+					return nil, fmt.Errorf("%w: %s", ErrNoData, strings.Join(reasons, "; "))
+				}
+			}
+
+			return nil, fmt.Errorf("request rejected: %s", strings.Join(reasons, "; "))
+		default:
+			return nil, fmt.Errorf("unexpected document %q", start.Name.Local)
+		}
+	}
+}
+
+// prices returns the prices between start and end in euros per kWh.
+//
+// When the document contains prices at several resolutions, only the finest one is used, and it is also the resolution
+// of the returned prices.
+func (doc *publicationMarketDocument) prices(start, end time.Time) ([]float64, error) {
+	var samples []sample
+
+	for _, ts := range doc.TimeSeries {
+		if ts.InDomain != "" && ts.InDomain != entsoeNetherlands {
+			continue
+		}
+
+		if ts.Currency != "EUR" || ts.Unit != "MWH" {
+			return nil, fmt.Errorf("unexpected unit %s/%s", ts.Currency, ts.Unit)
+		}
+
+		for _, p := range ts.Periods {
+			s, err := p.samples(ts.CurveType)
+			if err != nil {
+				return nil, err
+			}
+
+			samples = append(samples, s...)
+		}
+	}
+
+	if len(samples) == 0 {
+		return nil, ErrNoData
+	}
+
+	finest := slices.MinFunc(samples, func(a, b sample) int {
+		return cmp.Compare(a.resolution, b.resolution)
+	}).resolution
+
+	samples = slices.DeleteFunc(samples, func(s sample) bool {
+		return s.resolution != finest
+	})
+
+	return resample(samples, start, end, finest)
+}
+
+// samples expands the points of a period into one sample per position.
+//
+// With curve type A03 ("variable sized block"), a position is only present when its price differs from the previous
+// position, so missing positions repeat the price of the position before them. With curve type A01 ("sequential fixed
+// size block"), every position must be present.
+func (p entsoePeriod) samples(curveType string) ([]sample, error) {
+	start, err := time.Parse(entsoeTimeLayout, p.TimeInterval.Start)
+	if err != nil {
+		return nil, fmt.Errorf("parse period start: %w", err)
+	}
+
+	end, err := time.Parse(entsoeTimeLayout, p.TimeInterval.End)
+	if err != nil {
+		return nil, fmt.Errorf("parse period end: %w", err)
+	}
+
+	resolution, err := parseResolution(p.Resolution)
+	if err != nil {
+		return nil, err
+	}
+
+	n := int(end.Sub(start) / resolution)
+
+	prices := make([]float64, n)
+	present := make([]bool, n)
+
+	for _, point := range p.Points {
+		if point.Position < 1 || point.Position > n {
+			return nil, fmt.Errorf("position %d out of range [1, %d]", point.Position, n)
+		}
+
+		prices[point.Position-1] = point.Price
+		present[point.Position-1] = true
+	}
+
+	samples := make([]sample, 0, n)
+
+	for i, price := range prices {
+		if !present[i] {
+			if curveType != "A03" || i == 0 {
+				return nil, fmt.Errorf("%w: missing position %d", ErrIncomplete, i+1)
+			}
+
+			price = samples[i-1].price
+		}
+
+		samples = append(samples, sample{
+			start:      start.Add(time.Duration(i) * resolution),
+			resolution: resolution,
+			price:      price,
+		})
+	}
+
+	return samples, nil
+}
+
+// resample converts samples into one price per step between start and end, using the time-weighted average of the
+// samples that overlap each step.
+func resample(samples []sample, start, end time.Time, step time.Duration) ([]float64, error) {
+	n := int(end.Sub(start) / step)
+	prices := make([]float64, 0, n)
+
+	for i := range n {
+		slotStart := start.Add(time.Duration(i) * step)
+		slotEnd := slotStart.Add(step)
+
+		var (
+			sum     float64
+			covered time.Duration
+		)
+
+		for _, s := range samples {
+			from, to := slotStart, slotEnd
+			if s.start.After(from) {
+				from = s.start
+			}
+			if s.end().Before(to) {
+				to = s.end()
+			}
+
+			overlap := to.Sub(from)
+			if overlap <= 0 {
+				continue
+			}
+
+			sum += s.price * overlap.Hours()
+			covered += overlap
+		}
+
+		if covered != step {
+			return nil, fmt.Errorf("%w: no price for %s", ErrIncomplete, slotStart.Format(time.RFC3339))
+		}
+
+		// Convert from euros per MWh to euros per kWh.
+		prices = append(prices, sum/covered.Hours()/1000)
+	}
+
+	return prices, nil
+}
+
+// parseResolution parses an ISO 8601 duration of the form PTnM or PTnH.
+func parseResolution(s string) (time.Duration, error) {
+	rest, ok := strings.CutPrefix(s, "PT")
+	if !ok || len(rest) < 2 {
+		return 0, fmt.Errorf("unsupported resolution %q", s)
+	}
+
+	var unit time.Duration
+
+	switch rest[len(rest)-1] {
+	case 'M':
+		unit = time.Minute
+	case 'H':
+		unit = time.Hour
+	default:
+		return 0, fmt.Errorf("unsupported resolution %q", s)
+	}
+
+	n, err := strconv.Atoi(rest[:len(rest)-1])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("unsupported resolution %q", s)
+	}
+
+	return time.Duration(n) * unit, nil
+}
+
+// dayBounds returns the start of the given day and the start of the next day in Amsterdam.
+func dayBounds(day time.Time) (start, end time.Time) {
+	start = datetime.StartOfDay(day)
+
+	return start, start.AddDate(0, 0, 1)
+}
@@ -0,0 +1,228 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/heyajulia/savvy/internal/datetime"
+)
+
+func TestEntsoEPricesPT60M(t *testing.T) {
+	ps := parseFixture(t, "testdata/entsoe_pt60m.xml", time.Date(2025, time.March, 29, 0, 0, 0, 0, datetime.Location()))
+
+	if len(ps) != 24 {
+		t.Fatalf("expected 24 prices, got %d", len(ps))
+	}
+
+	tests := []struct {
+		hour int
+		want float64
+	}{
+		{0, 92.15},
+		// Position 5 is missing from the document, so it repeats position 4.
+		{4, 78.50},
+		{13, 35.00},
+		{19, 171.45},
+		{23, 97.31},
+	}
+
+	for _, tt := range tests {
+		if want := tt.want / 1000; !approximately(ps[tt.hour], want) {
+			t.Errorf("hour %d: got %v, want %v", tt.hour, ps[tt.hour], want)
+		}
+	}
+}
+
+func TestEntsoEPricesPT15M(t *testing.T) {
+	// October 26, 2025 is the DST end day, so it has 25 hours (100 quarter hours).
+	ps := parseFixture(t, "testdata/entsoe_pt15m.xml", time.Date(2025, time.October, 26, 0, 0, 0, 0, datetime.Location()))
+
+	if len(ps) != 100 {
+		t.Fatalf("expected 100 prices, got %d", len(ps))
+	}
+
+	tests := []struct {
+		quarter int
+		want    float64
+	}{
+		{0, 37.00},
+		{3, 43.00},
+		{52, -5.25},
+		// The last three quarters of this hour are missing from the document, so they repeat the first.
+		{53, -5.25},
+		{55, -5.25},
+	}
+
+	for _, tt := range tests {
+		if want := tt.want / 1000; !approximately(ps[tt.quarter], want) {
+			t.Errorf("quarter %d: got %v, want %v", tt.quarter, ps[tt.quarter], want)
+		}
+	}
+}
+
+func TestResample(t *testing.T) {
+	start := time.Date(2025, time.October, 25, 22, 0, 0, 0, time.UTC)
+
+	var samples []sample
+	for i, price := range []float64{10, 20, 30, 40, 50, 50, 50, 50} {
+		samples = append(samples, sample{
+			start:      start.Add(time.Duration(i) * 15 * time.Minute),
+			resolution: 15 * time.Minute,
+			price:      price,
+		})
+	}
+
+	ps, err := resample(samples, start, start.Add(2*time.Hour), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []float64{25, 50} {
+		if want := want / 1000; !approximately(ps[i], want) {
+			t.Errorf("hour %d: got %v, want %v", i, ps[i], want)
+		}
+	}
+
+	if _, err := resample(samples, start, start.Add(3*time.Hour), time.Hour); !errors.Is(err, ErrIncomplete) {
+		t.Errorf("expected %v, got %v", ErrIncomplete, err)
+	}
+}
+
+func TestEntsoENoData(t *testing.T) {
+	f, err := os.Open("testdata/entsoe_no_data.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if _, err := parseMarketDocument(f); !errors.Is(err, ErrNoData) {
+		t.Fatalf("expected %v, got %v", ErrNoData, err)
+	}
+}
+
+func TestEntsoEMissingPositionA01(t *testing.T) {
+	const doc = `<Publication_MarketDocument>
+	<type>A44</type>
+	<TimeSeries>
+		<currency_Unit.name>EUR</currency_Unit.name>
+		<price_Measure_Unit.name>MWH</price_Measure_Unit.name>
+		<curveType>A01</curveType>
+		<Period>
+			<timeInterval><start>2025-03-28T23:00Z</start><end>2025-03-29T01:00Z</end></timeInterval>
+			<resolution>PT60M</resolution>
+			<Point><position>1</position><price.amount>10</price.amount></Point>
+		</Period>
+	</TimeSeries>
+</Publication_MarketDocument>`
+
+	d, err := parseMarketDocument(strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2025, time.March, 28, 23, 0, 0, 0, time.UTC)
+
+	if _, err := d.prices(start, start.Add(2*time.Hour)); !errors.Is(err, ErrIncomplete) {
+		t.Fatalf("expected %v, got %v", ErrIncomplete, err)
+	}
+}
+
+func TestEntsoEFetch(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/entsoe_pt60m.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		for key, want := range map[string]string{
+			"securityToken": "token",
+			"documentType":  "A44",
+			"in_Domain":     "10YNL----------L",
+			"out_Domain":    "10YNL----------L",
+			"periodStart":   "202503282300",
+			"periodEnd":     "202503292300",
+		} {
+			if got := q.Get(key); got != want {
+				t.Errorf("for key %q, expected %q but got %q", key, want, got)
+			}
+		}
+
+		w.Write(fixture)
+	}))
+	defer srv.Close()
+
+	e := &EntsoE{token: "token", client: srv.Client(), baseURL: srv.URL}
+
+	ps, err := e.Fetch(context.Background(), time.Date(2025, time.March, 29, 12, 0, 0, 0, datetime.Location()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ps) != 24 {
+		t.Fatalf("expected 24 prices, got %d", len(ps))
+	}
+}
+
+func TestParseResolution(t *testing.T) {
+	tests := []struct {
+		give    string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"PT60M", time.Hour, false},
+		{"PT15M", 15 * time.Minute, false},
+		{"PT1H", time.Hour, false},
+		{"P1D", 0, true},
+		{"PT0M", 0, true},
+		{"PTM", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseResolution(tt.give)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseResolution(%q) error = %v, wantErr %v", tt.give, err, tt.wantErr)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf("parseResolution(%q) = %v, want %v", tt.give, got, tt.want)
+		}
+	}
+}
+
+func parseFixture(t *testing.T, name string, day time.Time) []float64 {
+	t.Helper()
+
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	d, err := parseMarketDocument(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start, end := dayBounds(day)
+
+	ps, err := d.prices(start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return ps
+}
+
+func approximately(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
@@ -0,0 +1,26 @@
+package source
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/heyajulia/savvy/internal/datetime"
+)
+
+// QueryParameters returns the EnergyZero query parameters to retrieve the prices for the given day.
+func QueryParameters(day time.Time) (url.Values, error) {
+	fromDateLocal, end := dayBounds(day)
+
+	tillDateLocal := end.Add(-time.Millisecond)
+
+	// Convert the local boundaries to UTC.
+	params := url.Values{
+		"fromDate":  {datetime.FormatRFC3339Milli(fromDateLocal.UTC())},
+		"tillDate":  {datetime.FormatRFC3339Milli(tillDateLocal.UTC())},
+		"interval":  {"4"}, // hourly; see EnergyZero
+		"usageType": {"1"},
+		"inclBtw":   {"false"},
+	}
+
+	return params, nil
+}
@@ -1,4 +1,4 @@
-package internal
+package source
 
 import (
 	"net/url"
@@ -15,13 +15,13 @@ func TestQueryParameters(t *testing.T) {
 
 	testCases := []struct {
 		name string
-		// Input time is the time when the query is made.
+		// Input time is a moment on the day to query prices for.
 		inputTime      time.Time
 		expectedParams url.Values
 	}{
 		{
-			name:      "Standard day: query on March 28 for March 29",
-			inputTime: time.Date(2025, time.March, 28, 12, 0, 0, 0, loc),
+			name:      "Standard day: March 29",
+			inputTime: time.Date(2025, time.March, 29, 12, 0, 0, 0, loc),
 			// March 29 in Amsterdam (CET, UTC+1) starts at local 00:00,
 			// which in UTC is 2025-03-28T23:00:00Z, and ends at 2025-03-29T22:59:59.999Z.
 			expectedParams: url.Values{
@@ -29,12 +29,12 @@ func TestQueryParameters(t *testing.T) {
 				"tillDate":  {"2025-03-29T22:59:59.999Z"},
 				"interval":  {"4"},
 				"usageType": {"1"},
-				"inclBtw":   {"true"},
+				"inclBtw":   {"false"},
 			},
 		},
 		{
-			name:      "DST start day: query on March 29 for March 30",
-			inputTime: time.Date(2025, time.March, 29, 12, 0, 0, 0, loc),
+			name:      "DST start day: March 30",
+			inputTime: time.Date(2025, time.March, 30, 12, 0, 0, 0, loc),
 			// March 30 is the DST transition day.
 			// Local midnight March 30 is before the DST jump (UTC+1) resulting in 2025-03-29T23:00:00Z,
 			// while the local end-of-day (23:59:59.999) after the jump (UTC+2) is 2025-03-30T21:59:59.999Z.
@@ -43,12 +43,12 @@ func TestQueryParameters(t *testing.T) {
 				"tillDate":  {"2025-03-30T21:59:59.999Z"},
 				"interval":  {"4"},
 				"usageType": {"1"},
-				"inclBtw":   {"true"},
+				"inclBtw":   {"false"},
 			},
 		},
 		{
-			name:      "Full DST day: query on March 30 for March 31",
-			inputTime: time.Date(2025, time.March, 30, 12, 0, 0, 0, loc),
+			name:      "Full DST day: March 31",
+			inputTime: time.Date(2025, time.March, 31, 12, 0, 0, 0, loc),
 			// March 31 in Amsterdam is fully in DST (UTC+2):
 			// Local midnight (00:00) converts to 2025-03-30T22:00:00Z,
 			// and end-of-day converts to 2025-03-31T21:59:59.999Z.
@@ -57,12 +57,12 @@ func TestQueryParameters(t *testing.T) {
 				"tillDate":  {"2025-03-31T21:59:59.999Z"},
 				"interval":  {"4"},
 				"usageType": {"1"},
-				"inclBtw":   {"true"},
+				"inclBtw":   {"false"},
 			},
 		},
 		{
-			name:      "DST end day: query on October 25 for October 26",
-			inputTime: time.Date(2025, time.October, 25, 12, 0, 0, 0, loc),
+			name:      "DST end day: October 26",
+			inputTime: time.Date(2025, time.October, 26, 12, 0, 0, 0, loc),
 			// October 26 is the DST end day.
 			// Local midnight October 26 (00:00) is still in DST (UTC+2), so in UTC that's 2025-10-25T22:00:00Z.
 			// After the fallback at 03:00, the end-of-day (23:59:59.999) is in CET (UTC+1),
@@ -72,7 +72,7 @@ func TestQueryParameters(t *testing.T) {
 				"tillDate":  {"2025-10-26T22:59:59.999Z"},
 				"interval":  {"4"},
 				"usageType": {"1"},
-				"inclBtw":   {"true"},
+				"inclBtw":   {"false"},
 			},
 		},
 	}
@@ -0,0 +1,54 @@
+// Package source provides the day-ahead energy price sources Savvy can retrieve prices from.
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrUnknown is returned by New when there is no source with the given name.
+var ErrUnknown = errors.New("unknown source")
+
+// Source retrieves the day-ahead energy prices for a single day.
+type Source interface {
+	// Fetch returns the prices for the given day in chronological order, at the resolution the source provides (one
+	// hour or a quarter of an hour). Prices are wholesale prices in euros per kWh, excluding VAT and any other charges.
+	Fetch(ctx context.Context, day time.Time) ([]float64, error)
+}
+
+// Options contains the settings that some sources need.
+type Options struct {
+	// EntsoEToken is the security token for the ENTSO-E Transparency Platform API.
+	EntsoEToken string
+
+	// BaseURL replaces the URL of the source's API, for example to point it at a local server in tests.
+	BaseURL string
+}
+
+// New returns the source with the given name.
+func New(name string, opts Options) (Source, error) {
+	switch name {
+	case "energyzero":
+		e := NewEnergyZero()
+		if opts.BaseURL != "" {
+			e.baseURL = opts.BaseURL
+		}
+
+		return e, nil
+	case "entsoe":
+		if opts.EntsoEToken == "" {
+			return nil, errors.New("source: entsoe: missing security token")
+		}
+
+		e := NewEntsoE(opts.EntsoEToken)
+		if opts.BaseURL != "" {
+			e.baseURL = opts.BaseURL
+		}
+
+		return e, nil
+	default:
+		return nil, fmt.Errorf("source: %w: %q", ErrUnknown, name)
+	}
+}
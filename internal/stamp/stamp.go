@@ -7,17 +7,29 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
+// Stamp records that something has been done in the current period, so that it is done only once. Stamps are empty
+// files in a directory.
 type Stamp struct {
-	dir string
+	dir  string
+	kind string
+	key  string
 }
 
+// New returns a Stamp for the current day.
 func New(directory string) *Stamp {
 	return &Stamp{dir: directory}
 }
 
+// NewKeyed returns a Stamp of the given kind (e.g. "week") for the period identified by key (e.g. "2025-W11"). Stamps
+// of different kinds can share a directory without pruning each other.
+func NewKeyed(directory, kind, key string) *Stamp {
+	return &Stamp{dir: directory, kind: kind, key: key}
+}
+
 func (s *Stamp) Stamp() error {
 	f, err := os.OpenFile(s.today(), os.O_CREATE|os.O_EXCL, 0644)
 	if err != nil {
@@ -50,10 +62,10 @@ func (s *Stamp) Prune() error {
 		return fmt.Errorf("stamp: read directory %q: %w", s.dir, err)
 	}
 
-	keep := date()
+	keep := s.name()
 
 	for _, entry := range entries {
-		if entry.Name() == keep {
+		if entry.Name() == keep || !s.sameKind(entry.Name()) {
 			continue
 		}
 
@@ -68,7 +80,25 @@ func (s *Stamp) Prune() error {
 }
 
 func (s *Stamp) today() string {
-	return filepath.Join(s.dir, date())
+	return filepath.Join(s.dir, s.name())
+}
+
+func (s *Stamp) name() string {
+	if s.kind == "" {
+		return date()
+	}
+
+	return s.kind + "-" + s.key
+}
+
+// sameKind reports whether name is the name of a stamp of the same kind as s.
+func (s *Stamp) sameKind(name string) bool {
+	if s.kind == "" {
+		_, err := time.Parse("2006-01-02", name)
+		return err == nil
+	}
+
+	return strings.HasPrefix(name, s.kind+"-")
 }
 
 func date() string {
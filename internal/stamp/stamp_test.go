@@ -0,0 +1,69 @@
+package stamp
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestKeyedStamp(t *testing.T) {
+	dir := t.TempDir()
+
+	week := NewKeyed(dir, "week", "2025-W11")
+
+	exists, err := week.Exists()
+	if err != nil || exists {
+		t.Fatalf("got %v and %v, want no stamp", exists, err)
+	}
+
+	if err := week.Stamp(); err != nil {
+		t.Fatal(err)
+	}
+
+	if exists, err := week.Exists(); err != nil || !exists {
+		t.Fatalf("got %v and %v, want stamp", exists, err)
+	}
+
+	if exists, err := NewKeyed(dir, "month", "2025-W11").Exists(); err != nil || exists {
+		t.Fatalf("got %v and %v, want no stamp of another kind", exists, err)
+	}
+}
+
+func TestPruneKeepsOtherKinds(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{"2000-01-01", "week-2025-W10", "week-2025-W11", "month-2025-02"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	day := New(dir)
+	if err := day.Stamp(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := day.Prune(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewKeyed(dir, "week", "2025-W11").Prune(); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, entry := range entries {
+		got = append(got, entry.Name())
+	}
+
+	want := []string{date(), "month-2025-02", "week-2025-W11"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
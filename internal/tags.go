@@ -0,0 +1,4 @@
+package internal
+
+// Tags are the hashtags (without the #) that posts are tagged with on Bluesky and Mastodon.
+var Tags = []string{"energie", "energiebericht", "groen", "duurzaam", "stroom", "klimaat", "klimaatverandering"}
@@ -1,6 +1,9 @@
 package option
 
-import "net/url"
+import (
+	"fmt"
+	"net/url"
+)
 
 type Option func(url.Values)
 
@@ -17,3 +20,10 @@ func Keyboard(keyboard string) Option {
 		v.Set("reply_markup", keyboard)
 	}
 }
+
+// ReplyTo sends the message as a reply to the message with the given ID.
+func ReplyTo(messageID int64) Option {
+	return func(v url.Values) {
+		v.Set("reply_parameters", fmt.Sprintf(`{"message_id":%d}`, messageID))
+	}
+}
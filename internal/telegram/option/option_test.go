@@ -37,6 +37,16 @@ func TestKeyboard(t *testing.T) {
 	}
 }
 
+func TestReplyTo(t *testing.T) {
+	values := url.Values{}
+
+	ReplyTo(42)(values)
+
+	if got, want := values.Get("reply_parameters"), `{"message_id":42}`; got != want {
+		t.Fatalf("expected reply_parameters to be %q, got %q", want, got)
+	}
+}
+
 func TestAllOptionsTogether(t *testing.T) {
 	values := url.Values{}
 	markup := `{"keyboard":[]}`
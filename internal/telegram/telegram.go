@@ -1,8 +1,10 @@
 package telegram
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -116,6 +118,28 @@ func (c *client) SendMessage(chatID chatid.ChatID, text string, options ...optio
 	return message, nil
 }
 
+// SendPhoto sends a PNG image, with an optional caption of at most 1024 characters.
+func (c *client) SendPhoto(chatID chatid.ChatID, photo []byte, caption string, options ...option.Option) (*message, error) {
+	parameters := url.Values{
+		"chat_id": {chatID.String()},
+	}
+
+	if caption != "" {
+		parameters.Set("caption", caption)
+	}
+
+	for _, o := range options {
+		o(parameters)
+	}
+
+	message, err := sendFile[message](c.token, "sendPhoto", parameters, "photo", "photo.png", photo)
+	if err != nil {
+		return nil, fmt.Errorf("telegram: sendPhoto: %w", err)
+	}
+
+	return message, nil
+}
+
 func (c *client) fireOff(method string, parameters url.Values) error {
 	if _, err := sendRequest[any](c.token, method, parameters); err != nil {
 		return fmt.Errorf("telegram: %s: %w", method, err)
@@ -125,18 +149,62 @@ func (c *client) fireOff(method string, parameters url.Values) error {
 }
 
 func sendRequest[T any](token, method string, parameters url.Values) (*T, error) {
-	type result[T any] struct {
-		OK          bool    `json:"ok"`
-		Description *string `json:"description"`
-		Result      T       `json:"result"`
+	resp, err := http.PostForm(methodURL(token, method), parameters)
+	if err != nil {
+		return nil, fmt.Errorf("send request: %w", err)
 	}
+	defer resp.Body.Close()
 
-	resp, err := http.PostForm(fmt.Sprintf("https://api.telegram.org/bot%s/%s", token, method), parameters)
+	return decodeResult[T](resp)
+}
+
+// sendFile is like sendRequest, but uploads data as a file in the given field, which requires a multipart request.
+func sendFile[T any](token, method string, parameters url.Values, field, filename string, data []byte) (*T, error) {
+	var body bytes.Buffer
+
+	w := multipart.NewWriter(&body)
+
+	for key, values := range parameters {
+		for _, value := range values {
+			if err := w.WriteField(key, value); err != nil {
+				return nil, fmt.Errorf("write field %s: %w", key, err)
+			}
+		}
+	}
+
+	part, err := w.CreateFormFile(field, filename)
+	if err != nil {
+		return nil, fmt.Errorf("create file field: %w", err)
+	}
+
+	if _, err := part.Write(data); err != nil {
+		return nil, fmt.Errorf("write file: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("close multipart writer: %w", err)
+	}
+
+	resp, err := http.Post(methodURL(token, method), w.FormDataContentType(), &body)
 	if err != nil {
 		return nil, fmt.Errorf("send request: %w", err)
 	}
 	defer resp.Body.Close()
 
+	return decodeResult[T](resp)
+}
+
+func methodURL(token, method string) string {
+	return fmt.Sprintf("https://api.telegram.org/bot%s/%s", token, method)
+}
+
+func decodeResult[T any](resp *http.Response) (*T, error) {
+	type result[T any] struct {
+		OK          bool    `json:"ok"`
+		Description *string `json:"description"`
+		Result      T       `json:"result"`
+	}
+
 	var r result[T]
 
 	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {